@@ -1,77 +1,170 @@
 package ququery
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Query interface {
 	Query() string
 }
 
+// env is the environment a query is rendered in. Subqueries are rendered in the
+// environment of the outer query.
+type env struct {
+	dialect   Dialect
+	tenant    any
+	hasTenant bool
+}
+
 type whereStructure struct {
 	column   string
 	operator string
 	rawQuery string
+	datePart datePart
+	render   func(dialect Dialect) (string, error)
+	build    func(e env) (string, []any, error)
+	args     []any
+	group    []whereStructure
+	filter   *filterCondition
+	isNot    bool
 	isAnd    bool
 	isRaw    bool
 }
 
-func prepareMultiWhereConditions(conditions []whereStructure) string {
-	var query string
+// prepareConditions joins the conditions with their AND/OR connectors.
+func prepareConditions(conditions []whereStructure, e env) (string, []any, error) {
+	var (
+		query string
+		args  []any
+	)
 
 	for i, condition := range conditions {
 		if i > 0 {
 			if condition.isAnd {
-				query += " AND"
+				query += " AND "
 			} else {
-				query += " OR"
+				query += " OR "
 			}
 		}
 
-		if condition.isRaw {
-			query += " " + condition.rawQuery
-		} else {
-			query += " " + condition.column + " " + condition.operator + " ?"
+		conditionQuery, conditionArgs, err := condition.prepare(e)
+		if err != nil {
+			return "", nil, err
 		}
+
+		query += conditionQuery
+		args = append(args, conditionArgs...)
+	}
+
+	return query, args, nil
+}
+
+func prepareWhereQuery(wheres []whereStructure, e env) (string, []any, error) {
+	if len(wheres) == 0 {
+		return "", nil, nil
+	}
+
+	conditions, args, err := prepareConditions(wheres, e)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return query
+	return "WHERE " + conditions, args, nil
 }
 
-func prepareWhereQuery(wheres []whereStructure) string {
-	var conditions string
+// prepare renders a single condition and returns the arguments of its placeholders.
+func (w whereStructure) prepare(e env) (string, []any, error) {
+	var query string
+
+	dialect := e.dialect
 
-	for i, where := range wheres {
-		if i == 0 {
-			conditions += "WHERE"
+	switch {
+	case w.group != nil:
+		group, args, err := prepareConditions(w.group, e)
+		if err != nil {
+			return "", nil, err
 		}
 
-		if i > 0 {
-			if where.isAnd {
-				conditions += " AND"
-			} else {
-				conditions += " OR"
-			}
+		if w.isNot {
+			return "NOT (" + group + ")", args, nil
 		}
 
-		if where.isRaw {
-			conditions += " " + where.rawQuery
-		} else {
-			conditions += " " + where.column + " " + where.operator + " ?"
+		return "(" + group + ")", args, nil
+	case w.build != nil:
+		return w.build(e)
+	case w.render != nil:
+		var err error
+
+		query, err = w.render(dialect)
+		if err != nil {
+			return "", nil, err
+		}
+	case w.isRaw:
+		query = w.rawQuery
+	default:
+		column := w.column
+		if w.datePart != "" {
+			column = dateExpression(dialect, w.datePart, w.column)
 		}
+
+		var err error
+
+		query, err = compare(dialect, column, w.operator)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	if w.args != nil {
+		return query, w.args, nil
 	}
 
-	return conditions
+	return query, placeholders(query), nil
 }
 
-func CountOver() string {
-	return "COUNT(*) OVER()"
+// placeholder is the argument of a "?" whose value is not known to the builder.
+// These arguments are filled by the values passed to Build.
+type placeholder struct{}
+
+// placeholders returns a placeholder argument for every "?" in query.
+// Rebinding treats every "?" as a bind variable, so counting them is enough.
+func placeholders(query string) []any {
+	args := make([]any, strings.Count(query, "?"))
+	for i := range args {
+		args[i] = placeholder{}
+	}
+
+	return args
 }
 
-func findTableFromEntity(entity string) string {
-	var table string
+// bindArgs fills the placeholder arguments with values in order.
+func bindArgs(args, values []any) ([]any, error) {
+	bound := make([]any, 0, len(args))
 
-	if entity[len(entity)-1:] == "y" {
-		table = entity[:len(entity)-1] + "ies"
-	} else {
-		table = entity + "s"
+	var next int
+
+	for _, arg := range args {
+		if _, ok := arg.(placeholder); !ok {
+			bound = append(bound, arg)
+			continue
+		}
+
+		if next == len(values) {
+			return nil, fmt.Errorf("%w: got %d values for more placeholders", ErrArgumentCount, len(values))
+		}
+
+		bound = append(bound, values[next])
+		next++
+	}
+
+	if next != len(values) {
+		return nil, fmt.Errorf("%w: got %d values for %d placeholders", ErrArgumentCount, len(values), next)
 	}
 
-	return table
+	return bound, nil
+}
+
+func CountOver() string {
+	return "COUNT(*) OVER()"
 }
@@ -0,0 +1,123 @@
+package ququery
+
+import "slices"
+
+// Clone returns a deep copy of the query. Builders change the query they are called on,
+// so a base query shared by many requests or goroutines must be cloned before it's changed.
+// Subqueries added to the query are shared by the copy.
+//
+// Example:
+//
+//	base := ququery.Select("users").Where("active")
+//
+//	admins := base.Clone().Where("role")
+//	log.Println(admins.Query()) => SELECT * FROM users WHERE active = $1 AND role = $2
+//
+//	recent := base.Clone().OrderBy("created_at", ququery.DESC)
+//	log.Println(recent.Query()) => SELECT * FROM users WHERE active = $1 ORDER BY created_at DESC
+func (q *SelectQuery) Clone() *SelectQuery {
+	c := *q
+	c.WhereContainer = q.WhereContainer.clone(&c)
+	c.columns = slices.Clone(q.columns)
+	c.subColumns = slices.Clone(q.subColumns)
+	c.orderBy = slices.Clone(q.orderBy)
+
+	if q.joins != nil {
+		c.joins = make([]join, len(q.joins))
+		for i, j := range q.joins {
+			j.on = cloneConditions(j.on)
+			j.using = slices.Clone(j.using)
+			c.joins[i] = j
+		}
+	}
+
+	if q.rank != nil {
+		rank := *q.rank
+		rank.columns = slices.Clone(rank.columns)
+		c.rank = &rank
+	}
+
+	return &c
+}
+
+// Clone returns a deep copy of the query.
+func (q *UpdateQuery) Clone() *UpdateQuery {
+	c := *q
+	c.WhereContainer = q.WhereContainer.clone(&c)
+	c.columns = slices.Clone(q.columns)
+
+	return &c
+}
+
+// Clone returns a deep copy of the query.
+func (q *DeleteQuery) Clone() *DeleteQuery {
+	c := *q
+	c.WhereContainer = q.WhereContainer.clone(&c)
+
+	return &c
+}
+
+// Clone returns a deep copy of the query.
+func (q *ExistsQuery) Clone() *ExistsQuery {
+	c := *q
+	c.WhereContainer = q.WhereContainer.clone(&c)
+
+	return &c
+}
+
+// Clone returns a deep copy of the where group.
+func (w *MultiWhere) Clone() *MultiWhere {
+	c := *w
+	c.WhereContainer = w.WhereContainer.clone(&c)
+
+	return &c
+}
+
+// Clone returns a deep copy of the query. Insert queries are values already, and their
+// methods don't change the query they are called on, so Clone only exists for consistency.
+func (q InsertQuery) Clone() InsertQuery {
+	q.columns = slices.Clone(q.columns)
+	q.returnings = slices.Clone(q.returnings)
+
+	return q
+}
+
+// Clone returns a deep copy of the query.
+func (q *AttachQuery) Clone() *AttachQuery {
+	c := *q
+	c.pivot.columns = slices.Clone(q.pivot.columns)
+	c.rows = slices.Clone(q.rows)
+
+	return &c
+}
+
+// clone returns a copy of the container for the query self, which doesn't share its conditions.
+func (c WhereContainer[T]) clone(self T) WhereContainer[T] {
+	c.self = self
+	c.conditions = cloneConditions(c.conditions)
+
+	return c
+}
+
+// cloneConditions returns a deep copy of the conditions and their groups.
+func cloneConditions(conditions []whereStructure) []whereStructure {
+	if conditions == nil {
+		return nil
+	}
+
+	cloned := make([]whereStructure, len(conditions))
+
+	for i, condition := range conditions {
+		condition.group = cloneConditions(condition.group)
+		condition.args = slices.Clone(condition.args)
+
+		if condition.filter != nil {
+			filter := *condition.filter
+			condition.filter = &filter
+		}
+
+		cloned[i] = condition
+	}
+
+	return cloned
+}
@@ -0,0 +1,103 @@
+package ququery_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestClone(t *testing.T) {
+	base := ququery.Select("users").
+		Columns("users.id").
+		Join("posts", "posts.user_id = users.id").
+		Where("active").
+		WhereGroup(func(w *ququery.MultiWhere) {
+			w.Where("role").OrWhere("admin")
+		})
+
+	admins := base.Clone().Where("verified").JoinOn("teams", func(j *ququery.JoinClause) {
+		j.On("teams.id", "=", "users.team_id")
+	})
+	recent := base.Clone().Where("created_at", ">").OrderBy("created_at", ququery.DESC)
+
+	update := ququery.Update("users").Set("name").Where("id")
+	deleteBase := ququery.Delete("users").Where("id")
+	existsBase := ququery.Exists("users").Where("email")
+	insert := ququery.Insert("users").Into("name")
+
+	testcases := testutil.Testcases{
+		"base": testutil.Testcase{
+			ExpectedSQL: "SELECT users.id FROM users INNER JOIN posts ON posts.user_id = users.id WHERE active = $1 AND (role = $2 OR admin = $3)",
+			Doc:         "the base query isn't changed by the clones",
+		}.Build(base, true, "editor", true),
+		"first clone": testutil.Testcase{
+			ExpectedSQL: "SELECT users.id FROM users INNER JOIN posts ON posts.user_id = users.id INNER JOIN teams ON teams.id = users.team_id WHERE active = $1 AND (role = $2 OR admin = $3) AND verified = $4",
+			Doc:         "a clone adds its own joins and conditions",
+		}.Build(admins, true, "editor", true, true),
+		"second clone": testutil.Testcase{
+			ExpectedSQL: "SELECT users.id FROM users INNER JOIN posts ON posts.user_id = users.id WHERE active = $1 AND (role = $2 OR admin = $3) AND created_at > $4 ORDER BY created_at DESC",
+			Doc:         "clones don't share their conditions",
+		}.Build(recent, true, "editor", true, "2024-01-01"),
+		"update": testutil.Testcase{
+			ExpectedSQL: "UPDATE users SET name = $1, email = $2 WHERE id = $3 AND active = $4",
+			Doc:         "clone an update",
+		}.Build(update.Clone().Set("email").Where("active"), "adel", "adel@example.com", 1, true),
+		"update base": testutil.Testcase{
+			ExpectedSQL: "UPDATE users SET name = $1 WHERE id = $2",
+			Doc:         "the update base is unchanged",
+		}.Build(update, "adel", 1),
+		"delete": testutil.Testcase{
+			ExpectedSQL: "DELETE FROM users WHERE id = $1 OR email = $2",
+			Doc:         "clone a delete",
+		}.Build(deleteBase.Clone().OrWhere("email"), 1, "adel@example.com"),
+		"delete base": testutil.Testcase{
+			ExpectedSQL: "DELETE FROM users WHERE id = $1",
+			Doc:         "the delete base is unchanged",
+		}.Build(deleteBase, 1),
+		"exists": testutil.Testcase{
+			ExpectedSQL: "SELECT EXISTS(SELECT true FROM users WHERE email = $1 AND active = $2)",
+			Doc:         "clone an exists",
+		}.Build(existsBase.Clone().Where("active"), "adel@example.com", true),
+		"exists base": testutil.Testcase{
+			ExpectedSQL: "SELECT EXISTS(SELECT true FROM users WHERE email = $1)",
+			Doc:         "the exists base is unchanged",
+		}.Build(existsBase, "adel@example.com"),
+		"insert": testutil.Testcase{
+			ExpectedSQL: "INSERT INTO users (name, email) VALUES ($1,$2)",
+			Doc:         "clone an insert",
+		}.Build(insert.Clone().Into("email"), "adel", "adel@example.com"),
+		"insert base": testutil.Testcase{
+			ExpectedSQL: "INSERT INTO users (name) VALUES ($1)",
+			Doc:         "the insert base is unchanged",
+		}.Build(insert, "adel"),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestClone_Concurrent(t *testing.T) {
+	base := ququery.Select("users").Where("active").Where("role")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			query := base.Clone().Where("team_id").Query()
+			if query != "SELECT * FROM users WHERE active = $1 AND role = $2 AND team_id = $3" {
+				t.Errorf("query: %s", query)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if query := base.Query(); query != "SELECT * FROM users WHERE active = $1 AND role = $2" {
+		t.Fatalf("base changed: %s", query)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 type DeleteQuery struct {
 	table string
+	force bool
 	WhereContainer[*DeleteQuery]
 }
 
@@ -18,12 +19,63 @@ func Delete(table string) *DeleteQuery {
 	return q
 }
 
-func (q *DeleteQuery) Query() string {
+func (q *DeleteQuery) build() (string, []any, error) {
+	if column, ok := softDeleteColumn(q.table); ok && !q.force {
+		where, args, err := prepareWhereQuery(tenantConditions(q.softDeleteConditions(q.table, withoutTrashed), q.table), q.env())
+		if err != nil {
+			return "", nil, err
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET %s = %s %s", q.table, column, now(q.dialect), where)
+
+		return sqlx.Rebind(q.dialect.bindType(), query), args, nil
+	}
+
+	// Force deletes include the soft deleted rows, unless OnlyTrashed limits them to those.
+	trashed := withTrashed
+	if q.trashed == onlyTrashed {
+		trashed = onlyTrashed
+	}
+
+	where, args, err := prepareWhereQuery(tenantConditions(q.softDeleteConditions(q.table, trashed), q.table), q.env())
+	if err != nil {
+		return "", nil, err
+	}
+
 	query := fmt.Sprintf(
 		`DELETE FROM %s %s`,
 		q.table,
-		prepareWhereQuery(q.conditions),
+		where,
 	)
 
-	return sqlx.Rebind(sqlx.DOLLAR, query)
+	return sqlx.Rebind(q.dialect.bindType(), query), args, nil
+}
+
+// Query returns the SQL of the query, or an empty string when the query can't be built.
+//
+// Deprecated: Query hides build errors, and some drivers run an empty query as a no-op.
+// Use Build, which returns the build error and the bound arguments.
+func (q *DeleteQuery) Query() string {
+	query, _, err := q.build()
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// Build returns the SQL of the query with its arguments in placeholder order.
+// Values are used for the placeholders whose value is not bound by the builder, in order.
+func (q *DeleteQuery) Build(values ...any) (string, []any, error) {
+	query, args, err := q.build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args, err = bindArgs(args, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
 }
@@ -0,0 +1,98 @@
+package ququery
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Dialect is the SQL flavour a query is rendered for.
+// The zero value is PostgreSQL, so builders keep their old behaviour
+// until another dialect is selected.
+type Dialect int
+
+const (
+	PostgreSQL Dialect = iota
+	MySQL
+	SQLite
+)
+
+// bindType returns the placeholder style used by the dialect.
+func (d Dialect) bindType() int {
+	if d == PostgreSQL {
+		return sqlx.DOLLAR
+	}
+
+	return sqlx.QUESTION
+}
+
+func (d Dialect) String() string {
+	switch d {
+	case PostgreSQL:
+		return "postgresql"
+	case MySQL:
+		return "mysql"
+	case SQLite:
+		return "sqlite"
+	}
+
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+type datePart string
+
+const (
+	dateOnly  datePart = "date"
+	timeOnly  datePart = "time"
+	yearPart  datePart = "year"
+	monthPart datePart = "month"
+	dayPart   datePart = "day"
+)
+
+// dateExpression wraps column with the function that extracts part of a
+// date or time value in the given dialect.
+func dateExpression(d Dialect, part datePart, column string) string {
+	switch d {
+	case MySQL:
+		switch part {
+		case dateOnly:
+			return fmt.Sprintf("DATE(%s)", column)
+		case timeOnly:
+			return fmt.Sprintf("TIME(%s)", column)
+		case yearPart:
+			return fmt.Sprintf("YEAR(%s)", column)
+		case monthPart:
+			return fmt.Sprintf("MONTH(%s)", column)
+		case dayPart:
+			return fmt.Sprintf("DAY(%s)", column)
+		}
+	case SQLite:
+		switch part {
+		case dateOnly:
+			return fmt.Sprintf("date(%s)", column)
+		case timeOnly:
+			return fmt.Sprintf("time(%s)", column)
+		case yearPart:
+			return fmt.Sprintf("CAST(strftime('%%Y', %s) AS INTEGER)", column)
+		case monthPart:
+			return fmt.Sprintf("CAST(strftime('%%m', %s) AS INTEGER)", column)
+		case dayPart:
+			return fmt.Sprintf("CAST(strftime('%%d', %s) AS INTEGER)", column)
+		}
+	default:
+		switch part {
+		case dateOnly:
+			return fmt.Sprintf("%s::date", column)
+		case timeOnly:
+			return fmt.Sprintf("%s::time", column)
+		case yearPart:
+			return fmt.Sprintf("EXTRACT(YEAR FROM %s)", column)
+		case monthPart:
+			return fmt.Sprintf("EXTRACT(MONTH FROM %s)", column)
+		case dayPart:
+			return fmt.Sprintf("EXTRACT(DAY FROM %s)", column)
+		}
+	}
+
+	return column
+}
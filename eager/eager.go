@@ -0,0 +1,305 @@
+// Package eager loads the relations of rows fetched with a select query. Every relation
+// is loaded with one more query per chunk of keys,
+//
+//	SELECT * FROM posts WHERE posts.user_id IN ($1, $2, ...)
+//
+// and the related rows are stitched onto their parent records, so has-many relations
+// don't duplicate their parents like joins do.
+//
+// Relations are found like ququery's With finds them, from ququery.Relations and the
+// naming strategy.
+package eager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/adel-hadadi/ququery"
+)
+
+// DefaultChunkSize is the number of keys loaded by a single query unless ChunkSize is used.
+const DefaultChunkSize = 1000
+
+// Queryer runs a query. *sql.DB, *sql.Tx, *sql.Conn and *sqlx.DB are queryers.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Record is a row by column name. Loaded relations are stored under their name,
+// as a Record for belongs-to and has-one relations and as a []Record otherwise.
+type Record map[string]any
+
+// Loader loads the relations of records.
+type Loader struct {
+	db        Queryer
+	dialect   ququery.Dialect
+	chunkSize int
+	relations []*relationNode
+}
+
+type relationNode struct {
+	name       string
+	constraint func(q *ququery.SelectQuery)
+	values     []any
+	children   []*relationNode
+}
+
+// New returns a loader running its queries on db with the dialect.
+func New(db Queryer, dialect ququery.Dialect) *Loader {
+	return &Loader{db: db, dialect: dialect, chunkSize: DefaultChunkSize}
+}
+
+// ChunkSize sets the number of keys loaded by a single query.
+func (l *Loader) ChunkSize(size int) *Loader {
+	if size > 0 {
+		l.chunkSize = size
+	}
+
+	return l
+}
+
+// With adds a relation to load. Nested relations are separated by dots, like "posts.comments",
+// and load their parents too. The constraint, which can be nil, can filter or sort the related
+// rows of the last relation of the path, and values fill its placeholders. When the constraint
+// selects columns, it must select the keys of the relation.
+//
+// Example:
+//
+//	users, err := eager.New(db, ququery.PostgreSQL).
+//		With("posts", func(q *ququery.SelectQuery) {
+//			q.Where("published").OrderBy("created_at", ququery.DESC)
+//		}, true).
+//		With("posts.comments", nil).
+//		Get(ctx, ququery.Select("users").Where("active"), true)
+//
+//	log.Println(users[0]["posts"].([]eager.Record)[0]["comments"])
+func (l *Loader) With(path string, constraint func(q *ququery.SelectQuery), values ...any) *Loader {
+	nodes := &l.relations
+
+	var node *relationNode
+
+	for _, name := range strings.Split(path, ".") {
+		node = nil
+
+		for _, n := range *nodes {
+			if n.name == name {
+				node = n
+				break
+			}
+		}
+
+		if node == nil {
+			node = &relationNode{name: name}
+			*nodes = append(*nodes, node)
+		}
+
+		nodes = &node.children
+	}
+
+	node.constraint = constraint
+	node.values = values
+
+	return l
+}
+
+// Get runs the query with the loader's dialect and loads the relations of its rows.
+// The query is left unchanged, so a base query can be shared.
+func (l *Loader) Get(ctx context.Context, q *ququery.SelectQuery, values ...any) ([]Record, error) {
+	query, args, err := q.Clone().Dialect(l.dialect).Build(values...)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := l.query(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.Load(ctx, q, records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Load loads the relations of records fetched from the table of q. The related rows
+// are queried with ctx, so they are limited to its tenant set with ququery.WithTenant.
+func (l *Loader) Load(ctx context.Context, q *ququery.SelectQuery, records []Record) error {
+	return l.load(ctx, q, records, l.relations)
+}
+
+func (l *Loader) load(ctx context.Context, q *ququery.SelectQuery, records []Record, nodes []*relationNode) error {
+	for _, node := range nodes {
+		relation := q.Relation(node.name)
+
+		children, err := l.loadRelation(ctx, relation, node, records)
+		if err != nil {
+			return fmt.Errorf("eager: load %s: %w", node.name, err)
+		}
+
+		if len(node.children) > 0 {
+			if err := l.load(ctx, ququery.Select(relation.Related), children, node.children); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadRelation loads the related rows of records, stitches them on and returns them.
+func (l *Loader) loadRelation(ctx context.Context, relation ququery.Relation, node *relationNode, records []Record) ([]Record, error) {
+	parentKey, childKey, column := relation.LocalKey, relation.ForeignKey, relation.Related+"."+relation.ForeignKey
+
+	switch relation.Kind {
+	case ququery.RelationMorphTo:
+		return nil, fmt.Errorf("morph-to relations can't be eager loaded")
+	case ququery.RelationBelongsTo:
+		parentKey, childKey, column = relation.ForeignKey, relation.OwnerKey, relation.Related+"."+relation.OwnerKey
+	case ququery.RelationManyToMany:
+		childKey, column = pivotKey(relation), relation.Pivot+"."+relation.ForeignKey
+	}
+
+	keys := distinctKeys(records, parentKey)
+
+	var children []Record
+
+	for start := 0; start < len(keys); start += l.chunkSize {
+		chunk := keys[start:min(start+l.chunkSize, len(keys))]
+
+		rows, err := l.queryChunk(ctx, relation, node, column, chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, rows...)
+	}
+
+	byKey := map[string][]Record{}
+	for _, child := range children {
+		key := keyOf(child[childKey])
+		byKey[key] = append(byKey[key], child)
+	}
+
+	for _, record := range records {
+		related := byKey[keyOf(record[parentKey])]
+		if record[parentKey] == nil {
+			related = nil
+		}
+
+		switch relation.Kind {
+		case ququery.RelationBelongsTo, ququery.RelationHasOne, ququery.RelationMorphOne:
+			if len(related) > 0 {
+				record[relation.Name] = related[0]
+			} else {
+				record[relation.Name] = nil
+			}
+		default:
+			if related == nil {
+				related = []Record{}
+			}
+
+			record[relation.Name] = related
+		}
+	}
+
+	return children, nil
+}
+
+func (l *Loader) queryChunk(ctx context.Context, relation ququery.Relation, node *relationNode, column string, keys []any) ([]Record, error) {
+	q := ququery.Select(relation.Related).Dialect(l.dialect).Context(ctx)
+
+	if relation.Kind == ququery.RelationManyToMany {
+		q.Columns(relation.Related+".*", fmt.Sprintf("%s.%s AS %s", relation.Pivot, relation.ForeignKey, pivotKey(relation))).
+			Join(relation.Pivot, fmt.Sprintf("%s.%s = %s.%s", relation.Pivot, relation.RelatedPivotKey, relation.Related, relation.RelatedKey))
+	}
+
+	q.WhereIn(column, keys...)
+
+	if relation.MorphType != "" {
+		q.WhereIn(relation.Related+"."+relation.MorphType, relation.MorphValue)
+	}
+
+	if node.constraint != nil {
+		q.Constrain(node.constraint)
+	}
+
+	query, args, err := q.Build(node.values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.query(ctx, query, args)
+}
+
+func (l *Loader) query(ctx context.Context, query string, args []any) ([]Record, error) {
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		record := make(Record, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+
+			record[column] = values[i]
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// pivotKey is the alias of the pivot column selected with the related rows of a many-to-many relation.
+func pivotKey(relation ququery.Relation) string {
+	return "pivot_" + relation.ForeignKey
+}
+
+// distinctKeys returns the non-nil values of column in records, without duplicates.
+func distinctKeys(records []Record, column string) []any {
+	var keys []any
+
+	seen := map[string]bool{}
+
+	for _, record := range records {
+		value := record[column]
+		if value == nil || seen[keyOf(value)] {
+			continue
+		}
+
+		seen[keyOf(value)] = true
+		keys = append(keys, value)
+	}
+
+	return keys
+}
+
+// keyOf returns a comparable form of a key, so 1 and int64(1) match.
+func keyOf(value any) string {
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,226 @@
+package eager_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/eager"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeDB answers queries from canned results keyed by the SQL and its arguments.
+type fakeDB struct {
+	results map[string]fakeRows
+	queries []string
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, fmt.Errorf("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, fmt.Errorf("not supported") }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, named []driver.NamedValue) (driver.Rows, error) {
+	args := make([]any, len(named))
+	for i, arg := range named {
+		args[i] = arg.Value
+	}
+
+	key := fmt.Sprint(query, " ", args)
+	c.db.queries = append(c.db.queries, key)
+
+	result, ok := c.db.results[key]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query %s", key)
+	}
+
+	return &rowsIterator{fakeRows: result}, nil
+}
+
+type rowsIterator struct {
+	fakeRows
+	next int
+}
+
+func (r *rowsIterator) Columns() []string { return r.columns }
+func (r *rowsIterator) Close() error      { return nil }
+
+func (r *rowsIterator) Next(dest []driver.Value) error {
+	if r.next == len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.next])
+	r.next++
+
+	return nil
+}
+
+func init() {
+	ququery.Relations.
+		HasMany("users", "posts", "posts", "user_id", "").
+		HasMany("posts", "comments", "comments", "post_id", "").
+		ManyToMany("users", "tags", "tags", "tag_user", "user_id", "tag_id")
+}
+
+func TestLoader_Get(t *testing.T) {
+	db := &fakeDB{results: map[string]fakeRows{
+		"SELECT * FROM users WHERE active = $1 [true]": {
+			columns: []string{"id", "role_id"},
+			rows:    [][]driver.Value{{int64(1), int64(7)}, {int64(2), nil}, {int64(3), int64(7)}},
+		},
+		"SELECT * FROM roles WHERE roles.id IN ($1) [7]": {
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(7), []byte("admin")}},
+		},
+		"SELECT * FROM posts WHERE posts.user_id IN ($1, $2) AND (published = $3 OR pinned = $4) [1 2 true true]": {
+			columns: []string{"id", "user_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1)}, {int64(11), int64(1)}},
+		},
+		"SELECT * FROM posts WHERE posts.user_id IN ($1) AND (published = $2 OR pinned = $3) [3 true true]": {
+			columns: []string{"id", "user_id"},
+			rows:    [][]driver.Value{{int64(12), int64(3)}},
+		},
+		"SELECT * FROM comments WHERE comments.post_id IN ($1, $2) [10 11]": {
+			columns: []string{"id", "post_id"},
+			rows:    [][]driver.Value{{int64(100), int64(11)}},
+		},
+		"SELECT * FROM comments WHERE comments.post_id IN ($1) [12]": {
+			columns: []string{"id", "post_id"},
+		},
+		"SELECT tags.*, tag_user.user_id AS pivot_user_id FROM tags INNER JOIN tag_user ON tag_user.tag_id = tags.id WHERE tag_user.user_id IN ($1, $2) [1 2]": {
+			columns: []string{"id", "pivot_user_id"},
+			rows:    [][]driver.Value{{int64(5), int64(2)}, {int64(6), int64(2)}},
+		},
+		"SELECT tags.*, tag_user.user_id AS pivot_user_id FROM tags INNER JOIN tag_user ON tag_user.tag_id = tags.id WHERE tag_user.user_id IN ($1) [3]": {
+			columns: []string{"id", "pivot_user_id"},
+		},
+	}}
+
+	conn := sql.OpenDB(db)
+	defer conn.Close()
+
+	users, err := eager.New(conn, ququery.PostgreSQL).
+		ChunkSize(2).
+		With("role", nil).
+		With("posts.comments", nil).
+		With("posts", func(q *ququery.SelectQuery) {
+			q.Where("published").OrWhere("pinned")
+		}, true, true).
+		With("tags", nil).
+		Get(context.Background(), ququery.Select("users").Where("active"), true)
+	if err != nil {
+		t.Fatalf("error: %v\nqueries: %v", err, db.queries)
+	}
+
+	admin := eager.Record{"id": int64(7), "name": "admin"}
+	comment := eager.Record{"id": int64(100), "post_id": int64(11)}
+
+	expected := []eager.Record{
+		{
+			"id": int64(1), "role_id": int64(7), "role": admin,
+			"posts": []eager.Record{
+				{"id": int64(10), "user_id": int64(1), "comments": []eager.Record{}},
+				{"id": int64(11), "user_id": int64(1), "comments": []eager.Record{comment}},
+			},
+			"tags": []eager.Record{},
+		},
+		{
+			"id": int64(2), "role_id": nil, "role": nil,
+			"posts": []eager.Record{},
+			"tags": []eager.Record{
+				{"id": int64(5), "pivot_user_id": int64(2)},
+				{"id": int64(6), "pivot_user_id": int64(2)},
+			},
+		},
+		{
+			"id": int64(3), "role_id": int64(7), "role": admin,
+			"posts": []eager.Record{
+				{"id": int64(12), "user_id": int64(3), "comments": []eager.Record{}},
+			},
+			"tags": []eager.Record{},
+		},
+	}
+
+	if diff := cmp.Diff(expected, users); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(db.queries) != 8 {
+		t.Errorf("expected 8 queries, got %d: %v", len(db.queries), db.queries)
+	}
+}
+
+func TestLoader_ConstraintArguments(t *testing.T) {
+	conn := sql.OpenDB(&fakeDB{})
+	defer conn.Close()
+
+	loader := eager.New(conn, ququery.PostgreSQL).With("posts", func(q *ququery.SelectQuery) {
+		q.Where("published")
+	})
+
+	err := loader.Load(context.Background(), ququery.Select("users"), []eager.Record{{"id": int64(1)}})
+	if !errors.Is(err, ququery.ErrArgumentCount) {
+		t.Errorf("expected %v, got %v", ququery.ErrArgumentCount, err)
+	}
+}
+
+func TestLoader_MorphMany(t *testing.T) {
+	ququery.Relations.
+		MorphType("post", "posts").
+		MorphMany("posts", "attachments", "attachments", "attachable")
+
+	db := &fakeDB{results: map[string]fakeRows{
+		"SELECT * FROM attachments WHERE attachments.attachable_id IN ($1) AND attachments.attachable_type IN ($2) [10 post]": {
+			columns: []string{"id", "attachable_id"},
+			rows:    [][]driver.Value{{int64(1), int64(10)}},
+		},
+	}}
+
+	conn := sql.OpenDB(db)
+	defer conn.Close()
+
+	posts := []eager.Record{{"id": int64(10)}}
+
+	if err := eager.New(conn, ququery.PostgreSQL).With("attachments", nil).Load(context.Background(), ququery.Select("posts"), posts); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	expected := []eager.Record{{"id": int64(10), "attachments": []eager.Record{{"id": int64(1), "attachable_id": int64(10)}}}}
+	if diff := cmp.Diff(expected, posts); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoader_GetLeavesQueryUnchanged(t *testing.T) {
+	db := &fakeDB{results: map[string]fakeRows{
+		"SELECT * FROM users WHERE active = ? [true]": {columns: []string{"id"}},
+	}}
+
+	conn := sql.OpenDB(db)
+	defer conn.Close()
+
+	base := ququery.Select("users").Where("active")
+
+	if _, err := eager.New(conn, ququery.MySQL).Get(context.Background(), base, true); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if query := base.Query(); query != "SELECT * FROM users WHERE active = $1" {
+		t.Errorf("query changed: %s", query)
+	}
+}
@@ -0,0 +1,29 @@
+package ququery
+
+import "errors"
+
+var (
+	// ErrUnsupported is returned by Build when the query uses a feature
+	// that the selected dialect does not support.
+	ErrUnsupported = errors.New("ququery: unsupported by dialect")
+
+	// ErrUnknownOperator is returned by Build when a where clause uses an
+	// operator that isn't known or registered with RegisterOperator.
+	ErrUnknownOperator = errors.New("ququery: unknown operator")
+
+	// ErrArgumentCount is returned by Build when the number of values passed
+	// to it doesn't match the placeholders left for the caller.
+	ErrArgumentCount = errors.New("ququery: wrong number of arguments")
+
+	// ErrUnknownRelation is returned by Build when a query uses a relation
+	// that isn't registered in Relations.
+	ErrUnknownRelation = errors.New("ququery: unknown relation")
+
+	// ErrUnknownScope is returned by Build when a query uses a scope
+	// that isn't registered with RegisterScope.
+	ErrUnknownScope = errors.New("ququery: unknown scope")
+
+	// ErrMissingTenant is returned by Build when a query uses a table registered
+	// with TenantTables and its context doesn't carry a tenant.
+	ErrMissingTenant = errors.New("ququery: missing tenant")
+)
@@ -18,12 +18,46 @@ func Exists(table string) *ExistsQuery {
 	return e
 }
 
-func (q *ExistsQuery) Query() string {
+func (q *ExistsQuery) build() (string, []any, error) {
+	where, args, err := prepareWhereQuery(tenantConditions(q.softDeleteConditions(q.table, q.trashed), q.table), q.env())
+	if err != nil {
+		return "", nil, err
+	}
+
 	query := fmt.Sprintf(
 		"SELECT EXISTS(SELECT true FROM %s %s)",
 		q.table,
-		prepareWhereQuery(q.conditions),
+		where,
 	)
 
-	return sqlx.Rebind(sqlx.DOLLAR, query)
+	return sqlx.Rebind(q.dialect.bindType(), query), args, nil
+}
+
+// Query returns the SQL of the query, or an empty string when the query can't be built.
+//
+// Deprecated: Query hides build errors, and some drivers run an empty query as a no-op.
+// Use Build, which returns the build error and the bound arguments.
+func (q *ExistsQuery) Query() string {
+	query, _, err := q.build()
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// Build returns the SQL of the query with its arguments in placeholder order.
+// Values are used for the placeholders whose value is not bound by the builder, in order.
+func (q *ExistsQuery) Build(values ...any) (string, []any, error) {
+	query, args, err := q.build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args, err = bindArgs(args, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
 }
@@ -0,0 +1,366 @@
+package ququery
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Allowlist maps the public names of filters to the columns they filter and
+// the filter operators allowed on them.
+//
+// The filter operators are "eq", "ne", "gt", "gte", "lt", "lte", "in", "nin",
+// "between", "null", "contains", "starts" and "ends". A field without operators only allows "eq".
+type Allowlist map[string]FilterField
+
+// FilterField is the column of a filter and the operators allowed on it.
+type FilterField struct {
+	Column    string
+	Operators []string
+}
+
+func (f FilterField) allows(op string) bool {
+	if len(f.Operators) == 0 {
+		return op == "eq"
+	}
+
+	for _, allowed := range f.Operators {
+		if allowed == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnknownFilterError is returned when a filter isn't in the allowlist.
+type UnknownFilterError struct {
+	Field string
+}
+
+func (e *UnknownFilterError) Error() string {
+	return fmt.Sprintf("ququery: unknown filter %q", e.Field)
+}
+
+// FilterOperatorError is returned when a filter uses an operator that isn't allowed on it.
+type FilterOperatorError struct {
+	Field    string
+	Operator string
+}
+
+func (e *FilterOperatorError) Error() string {
+	return fmt.Sprintf("ququery: operator %q is not allowed on filter %q", e.Operator, e.Field)
+}
+
+// FilterValueError is returned when the value of a filter doesn't fit its operator.
+type FilterValueError struct {
+	Field    string
+	Operator string
+	Reason   string
+}
+
+func (e *FilterValueError) Error() string {
+	return fmt.Sprintf("ququery: invalid value for filter %q %s: %s", e.Field, e.Operator, e.Reason)
+}
+
+// container gives the generic helpers of the package access to the conditions of a query.
+func (c *WhereContainer[T]) container() *WhereContainer[T] {
+	return c
+}
+
+// conditional is implemented by every builder that embeds WhereContainer.
+type conditional[T whereable] interface {
+	whereable
+	container() *WhereContainer[T]
+}
+
+// filterCondition is a validated filter. Conditions added from filters keep it,
+// so the where tree can be marshaled back with MarshalFilterTree.
+type filterCondition struct {
+	field    string
+	column   string
+	operator string
+	value    any
+}
+
+// ApplyFilters adds where clauses for the requested filters to the query. Filters are either
+// a map[string]any or a struct whose fields are tagged with `filter:"name,operator"`.
+//
+// In a map, a value is compared with "eq", a slice with "in" and nil with "null". A value can also be
+// a map[string]any of filter operators to values, like {"gte": 18, "lte": 30}.
+// Struct fields holding their zero value, nil pointers and unexported fields are skipped,
+// so use pointers to filter by zero values.
+//
+// Every filter is checked against the allowlist before the query is changed. Unknown filters,
+// operators that aren't allowed and values that don't fit their operator are reported with
+// UnknownFilterError, FilterOperatorError and FilterValueError.
+//
+// Example:
+//
+//	allow := ququery.Allowlist{
+//		"age":    {Column: "users.age", Operators: []string{"gte", "lte"}},
+//		"status": {Column: "users.status", Operators: []string{"eq", "in"}},
+//	}
+//
+//	q, err := ququery.ApplyFilters(ququery.Select("users"), map[string]any{
+//		"age":    map[string]any{"gte": 18},
+//		"status": []string{"active", "pending"},
+//	}, allow)
+//
+//	query, args, err := q.Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE users.age >= $1 AND users.status IN ($2, $3) [18 active pending]
+func ApplyFilters[T conditional[T]](q T, filters any, allow Allowlist) (T, error) {
+	requested, err := filterMap(filters)
+	if err != nil {
+		return q, err
+	}
+
+	conditions, err := allow.conditions(requested)
+	if err != nil {
+		return q, err
+	}
+
+	c := q.container()
+	for _, condition := range conditions {
+		c.applyFilter(condition, true)
+	}
+
+	return q, nil
+}
+
+// conditions validates the requested filters and returns them sorted by field and operator,
+// so the arguments of a query don't depend on map order.
+func (a Allowlist) conditions(requested map[string]map[string]any) ([]filterCondition, error) {
+	fields := make([]string, 0, len(requested))
+	for field := range requested {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	var conditions []filterCondition
+
+	for _, field := range fields {
+		if _, ok := a[field]; !ok {
+			return nil, &UnknownFilterError{Field: field}
+		}
+
+		ops := make([]string, 0, len(requested[field]))
+		for op := range requested[field] {
+			ops = append(ops, op)
+		}
+
+		sort.Strings(ops)
+
+		for _, op := range ops {
+			condition, err := a.condition(field, op, requested[field][op])
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return conditions, nil
+}
+
+// condition validates a single filter against the allowlist.
+func (a Allowlist) condition(field, op string, value any) (filterCondition, error) {
+	allowed, ok := a[field]
+	if !ok {
+		return filterCondition{}, &UnknownFilterError{Field: field}
+	}
+
+	if !allowed.allows(op) {
+		return filterCondition{}, &FilterOperatorError{Field: field, Operator: op}
+	}
+
+	value, err := filterValue(op, value)
+	if err != nil {
+		return filterCondition{}, &FilterValueError{Field: field, Operator: op, Reason: err.Error()}
+	}
+
+	return filterCondition{field: field, column: allowed.Column, operator: op, value: value}, nil
+}
+
+var filterOperators = map[string]string{
+	"eq":  "=",
+	"ne":  "!=",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
+// filterValue checks the value fits the operator and converts it to what the condition needs.
+func filterValue(op string, value any) (any, error) {
+	switch op {
+	case "eq", "ne", "gt", "gte", "lt", "lte":
+		if value == nil {
+			return nil, fmt.Errorf("value is nil")
+		}
+
+		return value, nil
+	case "in", "nin":
+		if values, ok := sliceOf(value); ok {
+			return values, nil
+		}
+
+		return []any{value}, nil
+	case "between":
+		values, ok := sliceOf(value)
+		if !ok || len(values) != 2 {
+			return nil, fmt.Errorf("between needs two values")
+		}
+
+		return values, nil
+	case "null":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			null, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("null needs a boolean")
+			}
+
+			return null, nil
+		}
+
+		return nil, fmt.Errorf("null needs a boolean")
+	case "contains", "starts", "ends":
+		term, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s needs a string", op)
+		}
+
+		return term, nil
+	}
+
+	return nil, fmt.Errorf("unknown operator")
+}
+
+func (c *WhereContainer[T]) applyFilter(f filterCondition, isAnd bool) {
+	switch f.operator {
+	case "in", "nin":
+		c.whereIn(f.column, f.value.([]any), f.operator == "nin", isAnd)
+	case "between":
+		values := f.value.([]any)
+		c.whereBetween(f.column, values[0], values[1], false, isAnd)
+	case "null":
+		null := " IS NULL"
+		if !f.value.(bool) {
+			null = " IS NOT NULL"
+		}
+
+		c.conditions = append(c.conditions, whereStructure{
+			isAnd:    isAnd,
+			isRaw:    true,
+			rawQuery: f.column + null,
+		})
+	case "contains":
+		c.whereEscapedLike(f.column, "%"+escapeLike(f.value.(string))+"%", isAnd)
+	case "starts":
+		c.whereEscapedLike(f.column, escapeLike(f.value.(string))+"%", isAnd)
+	case "ends":
+		c.whereEscapedLike(f.column, "%"+escapeLike(f.value.(string)), isAnd)
+	default:
+		c.whereValue(f.column, filterOperators[f.operator], f.value, isAnd)
+	}
+
+	c.conditions[len(c.conditions)-1].filter = &f
+}
+
+// filterMap turns the filters passed to ApplyFilters into field -> operator -> value.
+func filterMap(filters any) (map[string]map[string]any, error) {
+	requested := map[string]map[string]any{}
+
+	if m, ok := filters.(map[string]any); ok {
+		for field, value := range m {
+			switch v := value.(type) {
+			case nil:
+				requested[field] = map[string]any{"null": true}
+			case map[string]any:
+				requested[field] = v
+			default:
+				if _, ok := sliceOf(v); ok {
+					requested[field] = map[string]any{"in": v}
+				} else {
+					requested[field] = map[string]any{"eq": v}
+				}
+			}
+		}
+
+		return requested, nil
+	}
+
+	rv := reflect.ValueOf(filters)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ququery: filters must be a map[string]any or a struct, got %T", filters)
+	}
+
+	for i := 0; i < rv.NumField(); i++ {
+		structField := rv.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		tag, ok := structField.Tag.Lookup("filter")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		field := rv.Field(i)
+		if field.IsZero() {
+			continue
+		}
+
+		for field.Kind() == reflect.Pointer && !field.IsNil() {
+			field = field.Elem()
+		}
+
+		// A nil pointer at any depth isn't set.
+		if field.Kind() == reflect.Pointer {
+			continue
+		}
+
+		name, op, _ := strings.Cut(tag, ",")
+		if op == "" {
+			op = "eq"
+		}
+
+		if requested[name] == nil {
+			requested[name] = map[string]any{}
+		}
+
+		requested[name][op] = field.Interface()
+	}
+
+	return requested, nil
+}
+
+// sliceOf returns the elements of value when it is a slice or an array other than []byte.
+func sliceOf(value any) ([]any, bool) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	values := make([]any, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+
+	return values, true
+}
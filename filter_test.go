@@ -0,0 +1,134 @@
+package ququery_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+var userFilters = ququery.Allowlist{
+	"age":     {Column: "users.age", Operators: []string{"gte", "lte", "between"}},
+	"deleted": {Column: "users.deleted_at", Operators: []string{"null"}},
+	"name":    {Column: "users.name", Operators: []string{"eq", "contains"}},
+	"role":    {Column: "users.role_id", Operators: []string{"eq", "in", "nin"}},
+	"status":  {Column: "users.status"},
+}
+
+type searchUsers struct {
+	Name   *string `filter:"name,contains"`
+	MinAge int     `filter:"age,gte"`
+	Status string  `filter:"status"`
+	Page   int
+}
+
+func TestApplyFilters(t *testing.T) {
+	name := "adel"
+
+	fromMap, mapErr := ququery.ApplyFilters(ququery.Select("users").Where("active"), map[string]any{
+		"age":     map[string]any{"gte": 18, "lte": 30},
+		"deleted": nil,
+		"role":    []int{1, 2},
+		"status":  "active",
+	}, userFilters)
+
+	fromStruct, structErr := ququery.ApplyFilters(ququery.Update("users").Set("seen"), searchUsers{
+		Name:   &name,
+		MinAge: 18,
+		Page:   2,
+	}, userFilters)
+
+	between, betweenErr := ququery.ApplyFilters(ququery.Delete("users"), map[string]any{
+		"age":     map[string]any{"between": []any{18, 30}},
+		"deleted": map[string]any{"null": "false"},
+		"role":    map[string]any{"nin": 3},
+	}, userFilters)
+
+	if err := errors.Join(mapErr, structErr, betweenErr); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	testcases := testutil.Testcases{
+		"filters from a map": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE active = $1 AND users.age >= $2 AND users.age <= $3 AND users.deleted_at IS NULL AND users.role_id IN ($4, $5) AND users.status = $6",
+			ExpectedArgs: []any{true, 18, 30, 1, 2, "active"},
+			Doc:          "filters are applied in field and operator order",
+		}.Build(fromMap, true),
+		"filters from a struct": testutil.Testcase{
+			ExpectedSQL:  "UPDATE users SET seen = $1 WHERE users.age >= $2 AND users.name LIKE $3 ESCAPE '\\'",
+			ExpectedArgs: []any{true, 18, "%adel%"},
+			Doc:          "zero and untagged fields are skipped",
+		}.Build(fromStruct, true),
+		"between, not null and not in filters": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM users WHERE users.age BETWEEN $1 AND $2 AND users.deleted_at IS NOT NULL AND users.role_id NOT IN ($3)",
+			ExpectedArgs: []any{18, 30, 3},
+			Doc:          "delete users by filters",
+		}.Build(between),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestApplyFilters_StructFields(t *testing.T) {
+	var (
+		zero   = 0
+		nilAge *int
+	)
+
+	type search struct {
+		MinAge **int  `filter:"age,gte"`
+		MaxAge *int   `filter:"age,lte"`
+		status string `filter:"status"`
+	}
+
+	q, err := ququery.ApplyFilters(ququery.Select("users"), search{MinAge: &nilAge, MaxAge: &zero, status: "active"}, userFilters)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	testcases := testutil.Testcases{
+		"nil pointers and unexported fields": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE users.age <= $1",
+			ExpectedArgs: []any{0},
+			Doc:          "nested nil pointers aren't set and unexported fields are skipped",
+		}.Build(q),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestApplyFilters_Errors(t *testing.T) {
+	var (
+		unknown  *ququery.UnknownFilterError
+		operator *ququery.FilterOperatorError
+		value    *ququery.FilterValueError
+	)
+
+	testcases := map[string]struct {
+		filters any
+		target  any
+	}{
+		"unknown field":            {filters: map[string]any{"password": "x"}, target: &unknown},
+		"operator not allowed":     {filters: map[string]any{"age": 18}, target: &operator},
+		"default operator only eq": {filters: map[string]any{"status": []string{"a"}}, target: &operator},
+		"between with one value":   {filters: map[string]any{"age": map[string]any{"between": []int{1}}}, target: &value},
+		"null with a string":       {filters: map[string]any{"deleted": map[string]any{"null": "yes"}}, target: &value},
+		"contains with a number":   {filters: map[string]any{"name": map[string]any{"contains": 1}}, target: &value},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			q := ququery.Select("users")
+
+			_, err := ququery.ApplyFilters(q, tc.filters, userFilters)
+			if !errors.As(err, tc.target) {
+				t.Fatalf("error: got %v, want %T", err, tc.target)
+			}
+
+			if query := q.Query(); query != "SELECT * FROM users" {
+				t.Fatalf("query changed on error: %s", query)
+			}
+		})
+	}
+}
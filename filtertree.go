@@ -0,0 +1,336 @@
+package ququery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrFilterTreeTooDeep is returned when a filter tree nests deeper than its options allow.
+	ErrFilterTreeTooDeep = errors.New("ququery: filter tree is too deep")
+
+	// ErrFilterTreeTooLarge is returned when a filter tree has more conditions than its options allow.
+	ErrFilterTreeTooLarge = errors.New("ququery: filter tree has too many conditions")
+
+	// ErrNotFilter is returned by MarshalFilterTree for conditions that weren't added from filters.
+	ErrNotFilter = errors.New("ququery: condition is not a filter")
+)
+
+// FilterNode is a node of a filter tree, the JSON document used to store searches:
+//
+//	{"and": [{"field": "age", "op": ">", "value": 30}, {"or": [...]}, {"not": {...}}]}
+//
+// A node is either a group with one of And, Or and Not, or a condition with Field, Op and Value.
+// Op is one of the operators of Allowlist, and "=", "!=", ">", ">=", "<" and "<=" may be used
+// in place of "eq", "ne", "gt", "gte", "lt" and "lte".
+type FilterNode struct {
+	And   []FilterNode `json:"and,omitempty"`
+	Or    []FilterNode `json:"or,omitempty"`
+	Not   *FilterNode  `json:"not,omitempty"`
+	Field string       `json:"field,omitempty"`
+	Op    string       `json:"op,omitempty"`
+	Value any          `json:"value,omitempty"`
+}
+
+// FilterTreeOptions are the allowlist and the limits a filter tree is checked against.
+type FilterTreeOptions struct {
+	Allow Allowlist
+
+	// MaxDepth is the number of nested groups allowed. It defaults to 5.
+	MaxDepth int
+
+	// MaxConditions is the number of conditions allowed. It defaults to 50.
+	MaxConditions int
+}
+
+// FilterTreeError is returned for a filter tree that isn't well formed.
+type FilterTreeError struct {
+	Reason string
+}
+
+func (e *FilterTreeError) Error() string {
+	return "ququery: invalid filter tree: " + e.Reason
+}
+
+var filterSymbols = map[string]string{
+	"=":  "eq",
+	"!=": "ne",
+	">":  "gt",
+	">=": "gte",
+	"<":  "lt",
+	"<=": "lte",
+}
+
+// compiledFilter is a validated filter tree node.
+type compiledFilter struct {
+	group     []compiledFilter
+	isOr      bool
+	isNot     bool
+	condition filterCondition
+}
+
+// ApplyFilterTree adds the conditions of a JSON filter tree to the query as a group
+// with bound arguments. The whole tree is validated against the options before the query
+// is changed; fields and operators are reported like ApplyFilters does.
+//
+// Example:
+//
+//	q, err := ququery.ApplyFilterTree(ququery.Select("users"), []byte(`{"or": [
+//		{"field": "age", "op": ">", "value": 30},
+//		{"and": [{"field": "status", "op": "in", "value": ["a", "b"]}, {"field": "deleted", "op": "null", "value": true}]}
+//	]}`), ququery.FilterTreeOptions{Allow: allow})
+//
+//	query, args, err := q.Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE (age > $1 OR (status IN ($2, $3) AND deleted_at IS NULL)) [30 a b]
+func ApplyFilterTree[T conditional[T]](q T, data []byte, options FilterTreeOptions) (T, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+
+	var root FilterNode
+	if err := decoder.Decode(&root); err != nil {
+		return q, &FilterTreeError{Reason: err.Error()}
+	}
+
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return q, &FilterTreeError{Reason: "unexpected data after the filter tree"}
+	}
+
+	if options.MaxDepth == 0 {
+		options.MaxDepth = 5
+	}
+
+	if options.MaxConditions == 0 {
+		options.MaxConditions = 50
+	}
+
+	var count int
+
+	compiled, err := compileFilterNode(root, options, 0, &count)
+	if err != nil {
+		return q, err
+	}
+
+	applyFilterNode(q.container(), compiled, true)
+
+	return q, nil
+}
+
+func compileFilterNode(node FilterNode, options FilterTreeOptions, depth int, count *int) (compiledFilter, error) {
+	kinds := 0
+	for _, set := range []bool{node.And != nil, node.Or != nil, node.Not != nil, node.Field != ""} {
+		if set {
+			kinds++
+		}
+	}
+
+	if kinds != 1 {
+		return compiledFilter{}, &FilterTreeError{Reason: "a node needs exactly one of and, or, not and field"}
+	}
+
+	if node.Field != "" {
+		*count++
+		if *count > options.MaxConditions {
+			return compiledFilter{}, ErrFilterTreeTooLarge
+		}
+
+		op := node.Op
+		if name, ok := filterSymbols[op]; ok {
+			op = name
+		}
+
+		condition, err := options.Allow.condition(node.Field, op, jsonValue(node.Value))
+		if err != nil {
+			return compiledFilter{}, err
+		}
+
+		return compiledFilter{condition: condition}, nil
+	}
+
+	if depth == options.MaxDepth {
+		return compiledFilter{}, ErrFilterTreeTooDeep
+	}
+
+	if node.Not != nil {
+		child, err := compileFilterNode(*node.Not, options, depth+1, count)
+		if err != nil {
+			return compiledFilter{}, err
+		}
+
+		if child.group != nil && !child.isNot {
+			child.isNot = true
+
+			return child, nil
+		}
+
+		return compiledFilter{group: []compiledFilter{child}, isNot: true}, nil
+	}
+
+	children := node.And
+	if node.Or != nil {
+		children = node.Or
+	}
+
+	if len(children) == 0 {
+		return compiledFilter{}, &FilterTreeError{Reason: "a group needs at least one node"}
+	}
+
+	compiled := compiledFilter{group: make([]compiledFilter, len(children)), isOr: node.Or != nil}
+
+	for i, child := range children {
+		var err error
+
+		compiled.group[i], err = compileFilterNode(child, options, depth+1, count)
+		if err != nil {
+			return compiledFilter{}, err
+		}
+	}
+
+	return compiled, nil
+}
+
+// jsonValue converts the json.Number values of a decoded tree to int64 or float64.
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+
+		if n, err := v.Float64(); err == nil {
+			return n
+		}
+	case []any:
+		values := make([]any, len(v))
+		for i := range v {
+			values[i] = jsonValue(v[i])
+		}
+
+		return values
+	}
+
+	return value
+}
+
+func applyFilterNode[T whereable](c *WhereContainer[T], node compiledFilter, isAnd bool) {
+	if node.group == nil {
+		c.applyFilter(node.condition, isAnd)
+		return
+	}
+
+	c.whereGroup(func(q *MultiWhere) {
+		for i, child := range node.group {
+			applyFilterNode(q.container(), child, i == 0 || !node.isOr)
+		}
+	}, node.isNot, isAnd)
+}
+
+// MarshalFilterTree returns the where clauses of the query as a JSON filter tree, the
+// inverse of ApplyFilterTree. Every condition of the query must have been added from filters,
+// otherwise ErrNotFilter is returned. Since AND binds tighter than OR, groups mixing both are
+// written as an "or" of "and" groups.
+func MarshalFilterTree[T conditional[T]](q T) ([]byte, error) {
+	conditions := q.container().conditions
+	if len(conditions) == 0 {
+		return nil, &FilterTreeError{Reason: "the query has no conditions"}
+	}
+
+	var (
+		node FilterNode
+		err  error
+	)
+
+	if len(conditions) == 1 {
+		node, err = marshalFilterCondition(conditions[0])
+	} else {
+		node, err = marshalFilterGroup(conditions)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func marshalFilterGroup(conditions []whereStructure) (FilterNode, error) {
+	var (
+		runs [][]FilterNode
+		run  []FilterNode
+	)
+
+	for i, condition := range conditions {
+		if i > 0 && !condition.isAnd {
+			runs = append(runs, run)
+			run = nil
+		}
+
+		node, err := marshalFilterCondition(condition)
+		if err != nil {
+			return FilterNode{}, err
+		}
+
+		run = append(run, node)
+	}
+
+	runs = append(runs, run)
+
+	if len(runs) == 1 {
+		return FilterNode{And: runs[0]}, nil
+	}
+
+	or := make([]FilterNode, len(runs))
+	for i, run := range runs {
+		if len(run) == 1 {
+			or[i] = run[0]
+		} else {
+			or[i] = FilterNode{And: run}
+		}
+	}
+
+	return FilterNode{Or: or}, nil
+}
+
+func marshalFilterCondition(condition whereStructure) (FilterNode, error) {
+	if condition.group != nil {
+		node, err := marshalFilterGroup(condition.group)
+		if err != nil {
+			return FilterNode{}, err
+		}
+
+		// A "not" node is compiled to a negated group of its single child.
+		if condition.isNot && len(node.And) == 1 {
+			return FilterNode{Not: &node.And[0]}, nil
+		}
+
+		if condition.isNot {
+			return FilterNode{Not: &node}, nil
+		}
+
+		return node, nil
+	}
+
+	if condition.filter == nil {
+		return FilterNode{}, ErrNotFilter
+	}
+
+	op := condition.filter.operator
+	for symbol, name := range filterSymbols {
+		if name == op {
+			op = symbol
+		}
+	}
+
+	return FilterNode{Field: condition.filter.field, Op: op, Value: condition.filter.value}, nil
+}
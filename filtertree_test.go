@@ -0,0 +1,155 @@
+package ququery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+var treeOptions = ququery.FilterTreeOptions{
+	Allow: ququery.Allowlist{
+		"age":     {Column: "users.age", Operators: []string{"gt", "lte", "between"}},
+		"deleted": {Column: "users.deleted_at", Operators: []string{"null"}},
+		"name":    {Column: "users.name", Operators: []string{"contains"}},
+		"status":  {Column: "users.status", Operators: []string{"eq", "in"}},
+	},
+	MaxDepth:      4,
+	MaxConditions: 5,
+}
+
+const savedSearch = `{"and": [
+	{"field": "age", "op": ">", "value": 30},
+	{"or": [
+		{"field": "status", "op": "in", "value": ["a", "b"]},
+		{"not": {"and": [{"field": "name", "op": "contains", "value": "bot"}, {"field": "deleted", "op": "null", "value": false}]}}
+	]}
+]}`
+
+func TestApplyFilterTree(t *testing.T) {
+	tree, err := ququery.ApplyFilterTree(ququery.Select("users").Where("active"), []byte(savedSearch), treeOptions)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	leaf, err := ququery.ApplyFilterTree(ququery.Delete("users"), []byte(`{"field": "age", "op": "between", "value": [18, 30.5]}`), treeOptions)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	testcases := testutil.Testcases{
+		"nested filter tree": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE active = $1 AND (users.age > $2 AND (users.status IN ($3, $4) OR NOT (users.name LIKE $5 ESCAPE '\\' AND users.deleted_at IS NOT NULL)))",
+			ExpectedArgs: []any{true, int64(30), "a", "b", "%bot%"},
+			Doc:          "saved search with and, or and not groups",
+		}.Build(tree, true),
+		"single condition": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM users WHERE users.age BETWEEN $1 AND $2",
+			ExpectedArgs: []any{int64(18), 30.5},
+			Doc:          "a tree can be a single condition",
+		}.Build(leaf),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestApplyFilterTree_Errors(t *testing.T) {
+	var (
+		treeErr  *ququery.FilterTreeError
+		unknown  *ququery.UnknownFilterError
+		operator *ququery.FilterOperatorError
+	)
+
+	testcases := map[string]struct {
+		tree   string
+		target any
+		err    error
+	}{
+		"malformed json":     {tree: `{"and": [`, target: &treeErr},
+		"unknown key":        {tree: `{"xor": []}`, target: &treeErr},
+		"trailing data":      {tree: `{"field": "status", "op": "=", "value": "x"} garbage`, target: &treeErr},
+		"two trees":          {tree: `{"field": "status", "op": "=", "value": "x"} {}`, target: &treeErr},
+		"empty group":        {tree: `{"and": []}`, target: &treeErr},
+		"group and field":    {tree: `{"and": [{"field": "age", "op": ">", "value": 1}], "field": "age"}`, target: &treeErr},
+		"unknown field":      {tree: `{"field": "password", "op": "=", "value": "x"}`, target: &unknown},
+		"disallowed op":      {tree: `{"field": "age", "op": "=", "value": 1}`, target: &operator},
+		"too deep":           {tree: `{"and": [{"or": [{"and": [{"or": [{"not": {"field": "age", "op": ">", "value": 1}}]}]}]}]}`, err: ququery.ErrFilterTreeTooDeep},
+		"too many condition": {tree: `{"or": [{"field": "age", "op": ">", "value": 1}, {"field": "age", "op": ">", "value": 2}, {"field": "age", "op": ">", "value": 3}, {"field": "age", "op": ">", "value": 4}, {"field": "age", "op": ">", "value": 5}, {"field": "age", "op": ">", "value": 6}]}`, err: ququery.ErrFilterTreeTooLarge},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			q := ququery.Select("users")
+
+			_, err := ququery.ApplyFilterTree(q, []byte(tc.tree), treeOptions)
+
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("error: got %v, want %v", err, tc.err)
+			}
+
+			if tc.target != nil && !errors.As(err, tc.target) {
+				t.Fatalf("error: got %v, want %T", err, tc.target)
+			}
+
+			if query := q.Query(); query != "SELECT * FROM users" {
+				t.Fatalf("query changed on error: %s", query)
+			}
+		})
+	}
+}
+
+func TestMarshalFilterTree(t *testing.T) {
+	q, err := ququery.ApplyFilterTree(ququery.Select("users"), []byte(savedSearch), treeOptions)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	data, err := ququery.MarshalFilterTree(q)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var got, want any
+	if err := errors.Join(json.Unmarshal(data, &got), json.Unmarshal([]byte(savedSearch), &want)); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("diff: %s", diff)
+	}
+
+	filters, err := ququery.ApplyFilters(ququery.Select("users"), map[string]any{"age": map[string]any{"gt": 1, "lte": 9}}, treeOptions.Allow)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	data, err = ququery.MarshalFilterTree(filters.OrWhereNull("deleted_at").Where("id"))
+	if !errors.Is(err, ququery.ErrNotFilter) {
+		t.Fatalf("error: got %v (%s), want %v", err, data, ququery.ErrNotFilter)
+	}
+}
+
+func TestMarshalFilterTree_AppliedFilters(t *testing.T) {
+	q, err := ququery.ApplyFilters(ququery.Select("users"), map[string]any{"age": map[string]any{"gt": 1, "lte": 9}}, treeOptions.Allow)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	q, err = ququery.ApplyFilterTree(q, []byte(`{"or": [{"field": "status", "op": "=", "value": "a"}, {"field": "status", "op": "=", "value": "b"}]}`), treeOptions)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	data, err := ququery.MarshalFilterTree(q)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	want := `{"and":[{"field":"age","op":">","value":1},{"field":"age","op":"<=","value":9},{"or":[{"field":"status","op":"=","value":"a"},{"field":"status","op":"=","value":"b"}]}]}`
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Fatalf("diff: %s", diff)
+	}
+}
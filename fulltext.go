@@ -0,0 +1,122 @@
+package ququery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FullTextMode decides how the search term of a full-text condition is parsed.
+type FullTextMode int
+
+const (
+	// FullTextPlain matches every word of the term. It renders plainto_tsquery on
+	// PostgreSQL and NATURAL LANGUAGE MODE on MySQL.
+	FullTextPlain FullTextMode = iota
+
+	// FullTextWebsearch understands quoted phrases, "or" and "-" in the term. It renders
+	// websearch_to_tsquery on PostgreSQL and BOOLEAN MODE on MySQL.
+	FullTextWebsearch
+)
+
+type fullText struct {
+	columns []string
+	mode    FullTextMode
+
+	// term is the search term bound to the query by OrderByRank.
+	term any
+}
+
+func (f fullText) document() string {
+	if len(f.columns) == 1 {
+		return fmt.Sprintf("to_tsvector(%s)", f.columns[0])
+	}
+
+	columns := make([]string, len(f.columns))
+	for i, column := range f.columns {
+		columns[i] = fmt.Sprintf("coalesce(%s, '')", column)
+	}
+
+	return fmt.Sprintf("to_tsvector(%s)", strings.Join(columns, " || ' ' || "))
+}
+
+func (f fullText) tsquery() string {
+	if f.mode == FullTextWebsearch {
+		return "websearch_to_tsquery(?)"
+	}
+
+	return "plainto_tsquery(?)"
+}
+
+func (f fullText) against() string {
+	mode := "NATURAL LANGUAGE MODE"
+	if f.mode == FullTextWebsearch {
+		mode = "BOOLEAN MODE"
+	}
+
+	return fmt.Sprintf("MATCH(%s) AGAINST(? IN %s)", strings.Join(f.columns, ", "), mode)
+}
+
+func (f fullText) match(dialect Dialect) (string, error) {
+	switch dialect {
+	case PostgreSQL:
+		return fmt.Sprintf("%s @@ %s", f.document(), f.tsquery()), nil
+	case MySQL:
+		return f.against(), nil
+	}
+
+	return "", fmt.Errorf("%w: full-text search on %s", ErrUnsupported, dialect)
+}
+
+func (f fullText) rank(dialect Dialect) (string, error) {
+	switch dialect {
+	case PostgreSQL:
+		return fmt.Sprintf("ts_rank(%s, %s)", f.document(), f.tsquery()), nil
+	case MySQL:
+		return f.against(), nil
+	}
+
+	return "", fmt.Errorf("%w: full-text search on %s", ErrUnsupported, dialect)
+}
+
+// WhereFullText method adds a full-text search over the columns. The search term
+// is a single placeholder. It isn't supported on SQLite.
+//
+// Example:
+//
+//	query := ququery.Select("products").WhereFullText([]string{"name", "description"}, ququery.FullTextWebsearch).Query()
+//	log.Println(query) => SELECT * FROM products WHERE to_tsvector(coalesce(name, '') || ' ' || coalesce(description, '')) @@ websearch_to_tsquery($1)
+func (c *WhereContainer[T]) WhereFullText(columns []string, mode FullTextMode) T {
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd:  true,
+		render: fullText{columns: columns, mode: mode}.match,
+	})
+
+	return c.self
+}
+
+// OrWhereFullText method allows you to add an "or" clause to WhereFullText condition.
+func (c *WhereContainer[T]) OrWhereFullText(columns []string, mode FullTextMode) T {
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd:  false,
+		render: fullText{columns: columns, mode: mode}.match,
+	})
+
+	return c.self
+}
+
+// OrderByRank sorts the results by their relevance to a full-text search for term over the
+// columns, most relevant first. The term is bound to the query, so it isn't passed to Build.
+// Other OrderBy columns are sorted after the rank.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("products").
+//		WhereFullText([]string{"name"}, ququery.FullTextPlain).
+//		OrderByRank([]string{"name"}, ququery.FullTextPlain, "phone").
+//		Build("phone")
+//	log.Println(query, args) => SELECT * FROM products WHERE to_tsvector(name) @@ plainto_tsquery($1) ORDER BY ts_rank(to_tsvector(name), plainto_tsquery($2)) DESC [phone phone]
+func (q *SelectQuery) OrderByRank(columns []string, mode FullTextMode, term any) *SelectQuery {
+	q.rank = &fullText{columns: columns, mode: mode, term: term}
+
+	return q
+}
@@ -0,0 +1,63 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestWhereContainer_WhereFullText(t *testing.T) {
+	testcases := testutil.Testcases{
+		"plain full-text search on postgresql": {
+			Query:       ququery.Select("products").WhereFullText([]string{"name"}, ququery.FullTextPlain).Query(),
+			ExpectedSQL: "SELECT * FROM products WHERE to_tsvector(name) @@ plainto_tsquery($1)",
+			Doc:         "search products by name",
+		},
+		"websearch over several columns on postgresql": {
+			Query: ququery.Select("products").
+				Where("active").
+				OrWhereFullText([]string{"name", "description"}, ququery.FullTextWebsearch).
+				Query(),
+			ExpectedSQL: "SELECT * FROM products WHERE active = $1 OR to_tsvector(coalesce(name, '') || ' ' || coalesce(description, '')) @@ websearch_to_tsquery($2)",
+			Doc:         "search products by name and description",
+		},
+		"boolean mode full-text search on mysql": {
+			Query: ququery.Select("products").
+				Dialect(ququery.MySQL).
+				WhereFullText([]string{"name", "description"}, ququery.FullTextWebsearch).
+				Query(),
+			ExpectedSQL: "SELECT * FROM products WHERE MATCH(name, description) AGAINST(? IN BOOLEAN MODE)",
+			Doc:         "search products by name and description on mysql",
+		},
+		"full-text search on sqlite": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "full-text search is not supported on sqlite",
+		}.Build(ququery.Select("products").Dialect(ququery.SQLite).WhereFullText([]string{"name"}, ququery.FullTextPlain), "phone"),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_OrderByRank(t *testing.T) {
+	testcases := testutil.Testcases{
+		"order by rank on postgresql": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM products WHERE to_tsvector(name) @@ plainto_tsquery($1) ORDER BY ts_rank(to_tsvector(name), plainto_tsquery($2)) DESC, id ASC LIMIT $3",
+			ExpectedArgs: []any{"phone", "phone", 10},
+			Doc:          "search products and sort them by relevance",
+		}.Build(ququery.Select("products").
+			WhereFullText([]string{"name"}, ququery.FullTextPlain).
+			OrderByRank([]string{"name"}, ququery.FullTextPlain, "phone").
+			OrderBy("id", ququery.ASC).
+			Limit(), "phone", 10),
+		"order by rank on mysql": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM products ORDER BY MATCH(name) AGAINST(? IN NATURAL LANGUAGE MODE) DESC",
+			ExpectedArgs: []any{"phone"},
+			Doc:          "sort products by relevance on mysql",
+		}.Build(ququery.Select("products").
+			Dialect(ququery.MySQL).
+			OrderByRank([]string{"name"}, ququery.FullTextPlain, "phone")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
@@ -1,6 +1,7 @@
 package ququery
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,8 @@ type InsertQuery struct {
 	table      string
 	columns    []string
 	returnings []string
+	dialect    Dialect
+	ctx        context.Context
 }
 
 func Insert(table string) InsertQuery {
@@ -19,8 +22,54 @@ func Insert(table string) InsertQuery {
 	}
 }
 
+// Into adds columns to the insert. Calling it again appends more columns,
+// which together with When lets you insert optional columns.
 func (q InsertQuery) Into(columns ...string) InsertQuery {
-	q.columns = columns
+	q.columns = append(q.columns[:len(q.columns):len(q.columns)], columns...)
+
+	return q
+}
+
+// When calls f with the query when cond is true, so optional columns don't break the chain.
+//
+// Example:
+//
+//	query := ququery.Insert("users").
+//		Into("email").
+//		When(req.Phone != "", func(q ququery.InsertQuery) ququery.InsertQuery {
+//			return q.Into("phone")
+//		}).
+//		Query()
+func (q InsertQuery) When(cond bool, f func(q InsertQuery) InsertQuery) InsertQuery {
+	if cond {
+		return f(q)
+	}
+
+	return q
+}
+
+// Unless calls f with the query when cond is false.
+func (q InsertQuery) Unless(cond bool, f func(q InsertQuery) InsertQuery) InsertQuery {
+	return q.When(!cond, f)
+}
+
+// Dialect sets the SQL dialect the query is rendered for.
+func (q InsertQuery) Dialect(dialect Dialect) InsertQuery {
+	q.dialect = dialect
+
+	return q
+}
+
+// Context sets the context of the query. Inserts into a table registered with TenantTables
+// set the tenant column to the tenant of the context, set with WithTenant.
+//
+// Example:
+//
+//	ctx = ququery.WithTenant(ctx, 7)
+//	query, args, err := ququery.Insert("projects").Into("name").Context(ctx).Build("website")
+//	log.Println(query, args) => INSERT INTO projects (name, tenant_id) VALUES ($1,$2) [website 7]
+func (q InsertQuery) Context(ctx context.Context) InsertQuery {
+	q.ctx = ctx
 
 	return q
 }
@@ -31,19 +80,61 @@ func (q InsertQuery) Returning(columns ...string) InsertQuery {
 	return q
 }
 
+// Query returns the SQL of the query, or an empty string when the query can't be built.
+//
+// Deprecated: Query hides build errors, and some drivers run an empty query as a no-op.
+// Use Build, which returns the build error and the bound arguments.
 func (q InsertQuery) Query() string {
+	query, _, err := q.build()
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// Build returns the SQL of the query with its arguments in placeholder order.
+// Values are used for the placeholders whose value is not bound by the builder, in order.
+func (q InsertQuery) Build(values ...any) (string, []any, error) {
+	query, args, err := q.build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args, err = bindArgs(args, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
+}
+
+func (q InsertQuery) build() (string, []any, error) {
+	columns := q.columns
+	args := placeholders(prepareInsertQuery(columns))
+
+	column, tenant, err := insertTenant(q.ctx, q.table, q.columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if column != "" {
+		columns = append(columns[:len(columns):len(columns)], column)
+		args = append(args, tenant)
+	}
+
 	query := fmt.Sprintf(
 		`INSERT INTO %s (%s) VALUES (%s)`,
 		q.table,
-		strings.Join(q.columns, ", "),
-		prepareInsertQuery(q.columns),
+		strings.Join(columns, ", "),
+		prepareInsertQuery(columns),
 	)
 
 	if len(q.returnings) > 0 {
 		query += fmt.Sprintf(" RETURNING (%s)", strings.Join(q.returnings, ", "))
 	}
 
-	return sqlx.Rebind(sqlx.DOLLAR, query)
+	return sqlx.Rebind(q.dialect.bindType(), query), args, nil
 }
 
 func prepareInsertQuery(columns []string) string {
@@ -18,3 +18,26 @@ func TestInsertQuery_Insert(t *testing.T) {
 
 	testutil.RunTests(t, testcases, nil)
 }
+
+func TestInsertQuery_When(t *testing.T) {
+	testcases := testutil.Testcases{
+		"insert with optional columns": testutil.Testcase{
+			Query: ququery.Insert("users").
+				Into("email").
+				When(true, func(q ququery.InsertQuery) ququery.InsertQuery {
+					return q.Into("phone")
+				}).
+				When(false, func(q ququery.InsertQuery) ququery.InsertQuery {
+					return q.Into("name")
+				}).
+				Unless(false, func(q ququery.InsertQuery) ququery.InsertQuery {
+					return q.Into("role_id")
+				}).
+				Query(),
+			ExpectedSQL: "INSERT INTO users (email, phone, role_id) VALUES ($1,$2,$3)",
+			Doc:         "insert a user with the columns that have values",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
@@ -0,0 +1,262 @@
+package ququery
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	fullJoin  joinType = "FULL OUTER"
+	crossJoin joinType = "CROSS"
+)
+
+// JoinClause holds the conditions of a join built with JoinOn and LeftJoinOn.
+type JoinClause struct {
+	conditions []whereStructure
+}
+
+// On adds a condition comparing two columns to the join.
+func (j *JoinClause) On(first, operator, second string) *JoinClause {
+	return j.on(first, operator, second, true)
+}
+
+// OrOn method allows you to add an "or" condition comparing two columns to the join.
+func (j *JoinClause) OrOn(first, operator, second string) *JoinClause {
+	return j.on(first, operator, second, false)
+}
+
+func (j *JoinClause) on(first, operator, second string, isAnd bool) *JoinClause {
+	j.conditions = append(j.conditions, whereStructure{
+		isAnd: isAnd,
+		args:  []any{},
+		render: func(dialect Dialect) (string, error) {
+			return compareColumns(dialect, first, normalizeOperator(operator), second)
+		},
+	})
+
+	return j
+}
+
+// OnWhere adds a condition comparing a column to a value bound to the query.
+func (j *JoinClause) OnWhere(column, operator string, value any) *JoinClause {
+	return j.onWhere(column, operator, value, true)
+}
+
+// OrOnWhere method allows you to add an "or" clause to OnWhere condition.
+func (j *JoinClause) OrOnWhere(column, operator string, value any) *JoinClause {
+	return j.onWhere(column, operator, value, false)
+}
+
+func (j *JoinClause) onWhere(column, operator string, value any, isAnd bool) *JoinClause {
+	j.conditions = append(j.conditions, whereStructure{
+		column:   column,
+		operator: normalizeOperator(operator),
+		args:     []any{value},
+		isAnd:    isAnd,
+	})
+
+	return j
+}
+
+// compareColumns renders "first op second" after checking op is allowed on the dialect.
+func compareColumns(dialect Dialect, first, op, second string) (string, error) {
+	query, err := compare(dialect, first, op)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(query, "?") + second, nil
+}
+
+// JoinOn method used to add inner join whose conditions are built with a JoinClause,
+// so the join can filter by bound values.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").JoinOn("posts", func(j *ququery.JoinClause) {
+//		j.On("posts.user_id", "=", "users.id").OnWhere("posts.status", "=", "published")
+//	}).Build()
+//
+//	log.Println(query, args) => SELECT * FROM users INNER JOIN posts ON posts.user_id = users.id AND posts.status = $1 [published]
+func (q *SelectQuery) JoinOn(table string, f func(j *JoinClause)) *SelectQuery {
+	return q.joinOn(table, f, innerJoin)
+}
+
+// LeftJoinOn method used to add left join whose conditions are built with a JoinClause.
+// It has the same signature as JoinOn.
+func (q *SelectQuery) LeftJoinOn(table string, f func(j *JoinClause)) *SelectQuery {
+	return q.joinOn(table, f, leftJoin)
+}
+
+func (q *SelectQuery) joinOn(table string, f func(j *JoinClause), jType joinType) *SelectQuery {
+	clause := &JoinClause{}
+	f(clause)
+
+	var err error
+	if len(clause.conditions) == 0 {
+		err = fmt.Errorf("ququery: join on %s has no conditions", table)
+	}
+
+	q.joins = append(q.joins, join{
+		table: table,
+		on:    clause.conditions,
+		jType: jType,
+		err:   err,
+	})
+
+	return q
+}
+
+// FullJoin method used to add full outer join to your queries. It isn't supported on MySQL.
+//
+// Example:
+//
+//	query := ququery.Select("users").FullJoin("wallets", "wallets.user_id = users.id").Query()
+//	log.Println(query) => SELECT * FROM users FULL OUTER JOIN wallets ON wallets.user_id = users.id
+func (q *SelectQuery) FullJoin(table, constraints string) *SelectQuery {
+	q.joins = append(q.joins, join{
+		table:       table,
+		constraints: constraints,
+		jType:       fullJoin,
+	})
+
+	return q
+}
+
+// CrossJoin method used to add cross join to your queries.
+//
+// Example:
+//
+//	query := ququery.Select("sizes").CrossJoin("colors").Query()
+//	log.Println(query) => SELECT * FROM sizes CROSS JOIN colors
+func (q *SelectQuery) CrossJoin(table string) *SelectQuery {
+	q.joins = append(q.joins, join{
+		table: table,
+		jType: crossJoin,
+	})
+
+	return q
+}
+
+// JoinUsing method used to add inner join on columns with the same name in both tables.
+//
+// Example:
+//
+//	query := ququery.Select("orders").JoinUsing("invoices", "order_id", "tenant_id").Query()
+//	log.Println(query) => SELECT * FROM orders INNER JOIN invoices USING (order_id, tenant_id)
+func (q *SelectQuery) JoinUsing(table string, columns ...string) *SelectQuery {
+	q.joins = append(q.joins, join{
+		table: table,
+		using: columns,
+		jType: innerJoin,
+	})
+
+	return q
+}
+
+// JoinLateral method used to add inner join on a subquery that can refer to the
+// tables before it. It isn't supported on SQLite.
+//
+// Example:
+//
+//	latest := ququery.Select("posts").
+//		WhereColumn("posts.user_id", "=", "users.id").
+//		OrderBy("created_at", ququery.DESC).
+//		Paginate(1, 3)
+//
+//	query, args, err := ququery.Select("users").JoinLateral(latest, "latest").Build()
+//	log.Println(query, args) => SELECT * FROM users INNER JOIN LATERAL (SELECT * FROM posts WHERE posts.user_id = users.id ORDER BY created_at DESC LIMIT $1 OFFSET $2) AS latest ON TRUE [3 0]
+func (q *SelectQuery) JoinLateral(sub *SelectQuery, alias string) *SelectQuery {
+	return q.joinLateral(sub, alias, innerJoin)
+}
+
+// LeftJoinLateral method used to add left join on a lateral subquery.
+// It has the same signature as JoinLateral.
+func (q *SelectQuery) LeftJoinLateral(sub *SelectQuery, alias string) *SelectQuery {
+	return q.joinLateral(sub, alias, leftJoin)
+}
+
+func (q *SelectQuery) joinLateral(sub *SelectQuery, alias string, jType joinType) *SelectQuery {
+	q.joins = append(q.joins, join{
+		table:       alias,
+		sub:         sub,
+		lateral:     true,
+		constraints: "TRUE",
+		jType:       jType,
+	})
+
+	return q
+}
+
+// guardedInWhere reports whether the tenant guard of the joined table goes in the where clause
+// rather than the join. Cross and using joins have no constraints, and the constraints of
+// right and full joins don't filter the rows of the joined table.
+func (j join) guardedInWhere() bool {
+	return j.using != nil || j.jType == crossJoin || j.jType == rightJoin || j.jType == fullJoin
+}
+
+// prepare renders the join and returns the arguments of its placeholders.
+func (j join) prepare(e env) (string, []any, error) {
+	dialect := e.dialect
+
+	if j.err != nil {
+		return "", nil, j.err
+	}
+
+	if j.jType == fullJoin && dialect == MySQL {
+		return "", nil, fmt.Errorf("%w: full outer join on %s", ErrUnsupported, dialect)
+	}
+
+	if j.lateral && dialect == SQLite {
+		return "", nil, fmt.Errorf("%w: lateral join on %s", ErrUnsupported, dialect)
+	}
+
+	var args []any
+
+	table := j.table
+
+	if j.sub != nil {
+		sub, subArgs, err := j.sub.prepareSelectQuery(e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		table = fmt.Sprintf("(%s) AS %s", sub, j.table)
+		args = append(args, subArgs...)
+	}
+
+	if j.lateral {
+		table = "LATERAL " + table
+	}
+
+	query := fmt.Sprintf("%s JOIN %s", j.jType, table)
+
+	switch {
+	case j.using != nil:
+		query += fmt.Sprintf(" USING (%s)", strings.Join(j.using, ", "))
+	case j.jType != crossJoin:
+		on := j.on
+		if on == nil {
+			on = []whereStructure{{isRaw: true, rawQuery: j.constraints}}
+		}
+
+		if guard, ok := tenantGuard(j.table); ok && j.sub == nil && !j.guardedInWhere() {
+			// Raw constraints are grouped, so the guard applies to all of them even when they contain an "or".
+			if j.on == nil && j.constraints != "" {
+				on = []whereStructure{{group: on}}
+			}
+
+			on = guardConditions(on, guard)
+		}
+
+		constraints, onArgs, err := prepareConditions(on, e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += " ON " + constraints
+		args = append(args, onArgs...)
+	}
+
+	return query, args, nil
+}
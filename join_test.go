@@ -0,0 +1,89 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestSelectQuery_JoinTypes(t *testing.T) {
+	latest := ququery.Select("posts").
+		WhereColumn("posts.user_id", "=", "users.id").
+		OrderBy("created_at", ququery.DESC).
+		Paginate(1, 3)
+
+	testcases := testutil.Testcases{
+		"full outer join": testutil.Testcase{
+			Query:       ququery.Select("users").FullJoin("wallets", "wallets.user_id = users.id").Query(),
+			ExpectedSQL: "SELECT * FROM users FULL OUTER JOIN wallets ON wallets.user_id = users.id",
+			Doc:         "full outer join on postgresql",
+		},
+		"full outer join on mysql": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "mysql has no full outer join",
+		}.Build(ququery.Select("users").Dialect(ququery.MySQL).FullJoin("wallets", "wallets.user_id = users.id")),
+		"cross join": testutil.Testcase{
+			Query:       ququery.Select("sizes").CrossJoin("colors").Query(),
+			ExpectedSQL: "SELECT * FROM sizes CROSS JOIN colors",
+			Doc:         "cross join has no constraints",
+		},
+		"join using": testutil.Testcase{
+			Query:       ququery.Select("orders").JoinUsing("invoices", "order_id", "tenant_id").Query(),
+			ExpectedSQL: "SELECT * FROM orders INNER JOIN invoices USING (order_id, tenant_id)",
+			Doc:         "join on columns with the same name",
+		},
+		"lateral join": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users INNER JOIN LATERAL (SELECT * FROM posts WHERE posts.user_id = users.id ORDER BY created_at DESC LIMIT $1 OFFSET $2) AS latest ON TRUE WHERE active = $3",
+			ExpectedArgs: []any{3, 0, true},
+			Doc:          "the latest posts of every user",
+		}.Build(ququery.Select("users").JoinLateral(latest, "latest").Where("active"), true),
+		"left lateral join on mysql": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users LEFT JOIN LATERAL (SELECT * FROM posts WHERE posts.user_id = users.id ORDER BY created_at DESC LIMIT ? OFFSET ?) AS latest ON TRUE",
+			ExpectedArgs: []any{3, 0},
+			Doc:          "mysql supports lateral derived tables",
+		}.Build(ququery.Select("users").Dialect(ququery.MySQL).LeftJoinLateral(latest, "latest")),
+		"lateral join on sqlite": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "sqlite has no lateral joins",
+		}.Build(ququery.Select("users").Dialect(ququery.SQLite).JoinLateral(latest, "latest")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_JoinOn(t *testing.T) {
+	testcases := testutil.Testcases{
+		"join conditions with bound values": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users INNER JOIN posts ON posts.user_id = users.id AND posts.status = $1 WHERE users.id = $2",
+			ExpectedArgs: []any{"published", 7},
+			Doc:          "join arguments come before where arguments",
+		}.Build(ququery.Select("users").JoinOn("posts", func(j *ququery.JoinClause) {
+			j.On("posts.user_id", "=", "users.id").OnWhere("posts.status", "=", "published")
+		}).Where("users.id"), 7),
+		"left join with or conditions": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users LEFT JOIN messages ON messages.sender_id = users.id OR messages.receiver_id = users.id OR messages.system = ?",
+			ExpectedArgs: []any{true},
+			Doc:          "or conditions in a join",
+		}.Build(ququery.Select("users").Dialect(ququery.SQLite).LeftJoinOn("messages", func(j *ququery.JoinClause) {
+			j.On("messages.sender_id", "=", "users.id").OrOn("messages.receiver_id", "=", "users.id").OrOnWhere("messages.system", "=", true)
+		})),
+		"unknown join operator": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnknownOperator,
+			Doc:         "join operators are checked like where operators",
+		}.Build(ququery.Select("users").JoinOn("posts", func(j *ququery.JoinClause) {
+			j.On("posts.user_id", "==", "users.id")
+		})),
+		"where column": testutil.Testcase{
+			Query:       ququery.Select("users").WhereColumn("updated_at", ">", "created_at").OrWhereColumn("deleted_at", "is distinct from", "created_at").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE updated_at > created_at OR deleted_at IS DISTINCT FROM created_at",
+			Doc:         "compare two columns",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	if _, _, err := ququery.Select("users").JoinOn("posts", func(*ququery.JoinClause) {}).Build(); err == nil {
+		t.Error("expected an error for a join without conditions")
+	}
+}
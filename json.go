@@ -0,0 +1,269 @@
+package ququery
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	jsonIndex = regexp.MustCompile(`^[0-9]+$`)
+	jsonKey   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// jsonPath is a column followed by the keys written as "meta->settings->theme".
+// Numeric keys are array indexes.
+type jsonPath struct {
+	column string
+	keys   []string
+}
+
+func parseJSONPath(path string) jsonPath {
+	parts := strings.Split(path, "->")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	return jsonPath{column: parts[0], keys: parts[1:]}
+}
+
+// check reports a key containing "?", which would be taken for a placeholder
+// when the query is rebound, even inside the quoted key.
+func (p jsonPath) check() error {
+	for _, key := range p.keys {
+		if strings.Contains(key, "?") {
+			return fmt.Errorf("ququery: JSON key %q of %s can't contain \"?\"", key, p.column)
+		}
+	}
+
+	return nil
+}
+
+// postgres renders the path with "->" operators. When asText is true the
+// last key uses "->>" so the value is returned as text.
+func (p jsonPath) postgres(asText bool) string {
+	query := p.column
+
+	for i, key := range p.keys {
+		op := "->"
+		if asText && i == len(p.keys)-1 {
+			op = "->>"
+		}
+
+		if jsonIndex.MatchString(key) {
+			query += op + key
+		} else {
+			query += op + quoteLiteral(key)
+		}
+	}
+
+	return query
+}
+
+// selector renders the keys as a MySQL and SQLite path like '$.settings.theme'.
+func (p jsonPath) selector() string {
+	selector := "$"
+
+	for _, key := range p.keys {
+		switch {
+		case jsonIndex.MatchString(key):
+			selector += "[" + key + "]"
+		case jsonKey.MatchString(key):
+			selector += "." + key
+		default:
+			selector += `."` + strings.ReplaceAll(key, `"`, `\"`) + `"`
+		}
+	}
+
+	return quoteLiteral(selector)
+}
+
+// args renders the column and, when the path has keys, its selector as function arguments.
+func (p jsonPath) args() string {
+	if len(p.keys) == 0 {
+		return p.column
+	}
+
+	return p.column + ", " + p.selector()
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// WhereJSON method compares a value inside a JSON column. Keys of the path are separated by "->".
+// Keys can't contain "?", which would be taken for a placeholder.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereJSON("meta->settings->theme").Query()
+//	log.Println(query) => SELECT * FROM users WHERE meta->'settings'->>'theme' = $1
+func (c *WhereContainer[T]) WhereJSON(column ...string) T {
+	return c.whereJSON(column, true)
+}
+
+// OrWhereJSON method allows you to add an "or" clause to WhereJSON condition.
+func (c *WhereContainer[T]) OrWhereJSON(column ...string) T {
+	return c.whereJSON(column, false)
+}
+
+func (c *WhereContainer[T]) whereJSON(column []string, isAnd bool) T {
+	path := parseJSONPath(column[0])
+	op := c.checkOperator(column)
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		render: func(dialect Dialect) (string, error) {
+			if err := path.check(); err != nil {
+				return "", err
+			}
+
+			switch dialect {
+			case MySQL:
+				return compare(dialect, fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s))", path.args()), op)
+			case SQLite:
+				return compare(dialect, fmt.Sprintf("json_extract(%s)", path.args()), op)
+			}
+
+			return compare(dialect, path.postgres(true), op)
+		},
+	})
+
+	return c.self
+}
+
+// WhereJSONContains method verifies that the JSON value at the path contains the given value.
+// The value is encoded as JSON and bound to the query. It isn't supported on SQLite.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("posts").WhereJSONContains("meta->tags", []string{"go"}).Build()
+//	log.Println(query, args) => SELECT * FROM posts WHERE meta->'tags' @> $1 [["go"]]
+func (c *WhereContainer[T]) WhereJSONContains(column string, value any) T {
+	return c.whereJSONContains(column, value, true)
+}
+
+// OrWhereJSONContains method allows you to add an "or" clause to WhereJSONContains condition.
+func (c *WhereContainer[T]) OrWhereJSONContains(column string, value any) T {
+	return c.whereJSONContains(column, value, false)
+}
+
+func (c *WhereContainer[T]) whereJSONContains(column string, value any, isAnd bool) T {
+	path := parseJSONPath(column)
+	encoded, encodeErr := json.Marshal(value)
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		args:  []any{string(encoded)},
+		render: func(dialect Dialect) (string, error) {
+			if encodeErr != nil {
+				return "", fmt.Errorf("ququery: encode JSON value of %s: %w", column, encodeErr)
+			}
+
+			if err := path.check(); err != nil {
+				return "", err
+			}
+
+			switch dialect {
+			case MySQL:
+				if len(path.keys) == 0 {
+					return fmt.Sprintf("JSON_CONTAINS(%s, ?)", path.column), nil
+				}
+
+				return fmt.Sprintf("JSON_CONTAINS(%s, ?, %s)", path.column, path.selector()), nil
+			case SQLite:
+				return "", fmt.Errorf("%w: JSON contains on %s", ErrUnsupported, dialect)
+			}
+
+			return fmt.Sprintf("%s @> ?", path.postgres(false)), nil
+		},
+	})
+
+	return c.self
+}
+
+// WhereJSONLength method compares the length of the JSON array at the path.
+//
+// Example:
+//
+//	query := ququery.Select("posts").WhereJSONLength("meta->tags", ">").Query()
+//	log.Println(query) => SELECT * FROM posts WHERE jsonb_array_length(meta->'tags') > $1
+func (c *WhereContainer[T]) WhereJSONLength(column ...string) T {
+	return c.whereJSONLength(column, true)
+}
+
+// OrWhereJSONLength method allows you to add an "or" clause to WhereJSONLength condition.
+func (c *WhereContainer[T]) OrWhereJSONLength(column ...string) T {
+	return c.whereJSONLength(column, false)
+}
+
+func (c *WhereContainer[T]) whereJSONLength(column []string, isAnd bool) T {
+	path := parseJSONPath(column[0])
+	op := c.checkOperator(column)
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		render: func(dialect Dialect) (string, error) {
+			if err := path.check(); err != nil {
+				return "", err
+			}
+
+			switch dialect {
+			case MySQL:
+				return compare(dialect, fmt.Sprintf("JSON_LENGTH(%s)", path.args()), op)
+			case SQLite:
+				return compare(dialect, fmt.Sprintf("json_array_length(%s)", path.args()), op)
+			}
+
+			return compare(dialect, fmt.Sprintf("jsonb_array_length(%s)", path.postgres(false)), op)
+		},
+	})
+
+	return c.self
+}
+
+// WhereJSONHasKey method verifies that the last key of the path exists in the JSON column.
+// On PostgreSQL the key is looked up with "->" rather than the "?" operator,
+// which would be taken for a placeholder.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereJSONHasKey("meta->settings->theme").Query()
+//	log.Println(query) => SELECT * FROM users WHERE meta->'settings'->'theme' IS NOT NULL
+func (c *WhereContainer[T]) WhereJSONHasKey(column string) T {
+	return c.whereJSONHasKey(column, true)
+}
+
+// OrWhereJSONHasKey method allows you to add an "or" clause to WhereJSONHasKey condition.
+func (c *WhereContainer[T]) OrWhereJSONHasKey(column string) T {
+	return c.whereJSONHasKey(column, false)
+}
+
+func (c *WhereContainer[T]) whereJSONHasKey(column string, isAnd bool) T {
+	path := parseJSONPath(column)
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		render: func(dialect Dialect) (string, error) {
+			if len(path.keys) == 0 {
+				return "", fmt.Errorf("ququery: JSON path %q has no key", column)
+			}
+
+			if err := path.check(); err != nil {
+				return "", err
+			}
+
+			switch dialect {
+			case MySQL:
+				return fmt.Sprintf("JSON_CONTAINS_PATH(%s, 'one', %s)", path.column, path.selector()), nil
+			case SQLite:
+				return fmt.Sprintf("json_type(%s) IS NOT NULL", path.args()), nil
+			}
+
+			return fmt.Sprintf("%s IS NOT NULL", path.postgres(false)), nil
+		},
+	})
+
+	return c.self
+}
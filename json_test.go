@@ -0,0 +1,124 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestWhereContainer_WhereJSON(t *testing.T) {
+	testcases := testutil.Testcases{
+		"where json on postgresql": {
+			Query:       ququery.Select("users").WhereJSON("meta->settings->theme").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE meta->'settings'->>'theme' = $1",
+			Doc:         "select users by a value of their settings",
+		},
+		"where json with array index and operator": {
+			Query:       ququery.Select("users").Where("id").OrWhereJSON("meta->scores->0", ">").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE id = $1 OR meta->'scores'->>0 > $2",
+			Doc:         "select users by the first element of a json array",
+		},
+		"where json on mysql": {
+			Query:       ququery.Select("users").Dialect(ququery.MySQL).WhereJSON("meta->settings->theme").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE JSON_UNQUOTE(JSON_EXTRACT(meta, '$.settings.theme')) = ?",
+			Doc:         "select users by a value of their settings on mysql",
+		},
+		"where json on sqlite": {
+			Query:       ququery.Select("users").Dialect(ququery.SQLite).WhereJSON("meta->scores->0").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE json_extract(meta, '$.scores[0]') = ?",
+			Doc:         "select users by the first element of a json array on sqlite",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_WhereJSONContains(t *testing.T) {
+	testcases := testutil.Testcases{
+		"where json contains on postgresql": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM posts WHERE user_id = $1 AND meta->'tags' @> $2",
+			ExpectedArgs: []any{10, `["go"]`},
+			Doc:          "select posts of a user tagged with go",
+		}.Build(ququery.Select("posts").Where("user_id").WhereJSONContains("meta->tags", []string{"go"}), 10),
+		"or where json contains on mysql": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM posts WHERE JSON_CONTAINS(tags, ?) OR JSON_CONTAINS(meta, ?, '$.tags')",
+			ExpectedArgs: []any{`"go"`, `["go"]`},
+			Doc:          "select posts tagged with go on mysql",
+		}.Build(ququery.Select("posts").
+			Dialect(ququery.MySQL).
+			WhereJSONContains("tags", "go").
+			OrWhereJSONContains("meta->tags", []string{"go"})),
+		"where json contains on sqlite": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "json contains is not supported on sqlite",
+		}.Build(ququery.Select("posts").Dialect(ququery.SQLite).WhereJSONContains("tags", "go")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_WhereJSONLength(t *testing.T) {
+	testcases := testutil.Testcases{
+		"where json length on postgresql": {
+			Query:       ququery.Select("posts").WhereJSONLength("meta->tags", ">").Query(),
+			ExpectedSQL: "SELECT * FROM posts WHERE jsonb_array_length(meta->'tags') > $1",
+			Doc:         "select posts with more than x tags",
+		},
+		"where json length on mysql": {
+			Query:       ququery.Select("posts").Dialect(ququery.MySQL).WhereJSONLength("tags").Query(),
+			ExpectedSQL: "SELECT * FROM posts WHERE JSON_LENGTH(tags) = ?",
+			Doc:         "select posts with x tags on mysql",
+		},
+		"or where json length on sqlite": {
+			Query:       ququery.Select("posts").Dialect(ququery.SQLite).Where("id").OrWhereJSONLength("meta->tags", "<").Query(),
+			ExpectedSQL: "SELECT * FROM posts WHERE id = ? OR json_array_length(meta, '$.tags') < ?",
+			Doc:         "select posts with less than x tags on sqlite",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_WhereJSONHasKey(t *testing.T) {
+	testcases := testutil.Testcases{
+		"where json has key on postgresql": {
+			Query:       ququery.Select("users").WhereJSONHasKey("meta->settings->theme").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE meta->'settings'->'theme' IS NOT NULL",
+			Doc:         "select users that chose a theme",
+		},
+		"where json has key on mysql": {
+			Query:       ququery.Select("users").Dialect(ququery.MySQL).WhereJSONHasKey("meta->settings->theme").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE JSON_CONTAINS_PATH(meta, 'one', '$.settings.theme')",
+			Doc:         "select users that chose a theme on mysql",
+		},
+		"or where json has key on sqlite": {
+			Query:       ququery.Select("users").Dialect(ququery.SQLite).Where("id").OrWhereJSONHasKey("meta->theme").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE id = ? OR json_type(meta, '$.theme') IS NOT NULL",
+			Doc:         "select users that chose a theme on sqlite",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_JSONKeyWithQuestionMark(t *testing.T) {
+	queries := map[string]*ququery.SelectQuery{
+		"where json":          ququery.Select("users").WhereJSON("meta->what?"),
+		"where json contains": ququery.Select("users").Dialect(ququery.MySQL).WhereJSONContains("meta->what?", 1),
+		"where json length":   ququery.Select("users").Dialect(ququery.SQLite).WhereJSONLength("meta->what?"),
+		"where json has key":  ququery.Select("users").WhereJSONHasKey("meta->what?"),
+	}
+
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := q.Build(1); err == nil {
+				t.Fatal("error: got nil for a key containing \"?\"")
+			}
+
+			if query := q.Query(); query != "" {
+				t.Fatalf("query: got %s, want an empty string", query)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package ququery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MorphType maps a type stored in the type columns of polymorphic relations to its table.
+//
+// Example:
+//
+//	ququery.Relations.
+//		MorphType("post", "posts").
+//		MorphType("video", "videos").
+//		MorphTo("comments", "commentable", "", "").
+//		MorphMany("posts", "comments", "comments", "commentable")
+func (r *RelationRegistry) MorphType(morphType, table string) *RelationRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.morphTypes[morphType] = table
+
+	return r
+}
+
+// morphTypeOf returns the type of table, or the table itself when it has no type.
+// When types share a table, the first of them in sorted order is returned.
+func (r *RelationRegistry) morphTypeOf(table string) string {
+	morphTypes := make([]string, 0, len(r.morphTypes))
+	for morphType := range r.morphTypes {
+		morphTypes = append(morphTypes, morphType)
+	}
+
+	sort.Strings(morphTypes)
+
+	for _, morphType := range morphTypes {
+		if r.morphTypes[morphType] == table {
+			return morphType
+		}
+	}
+
+	return table
+}
+
+// MorphTo registers a relation whose rows of table refer to rows of the tables of every
+// registered MorphType: the type column holds the type and the id column the key of the row.
+// They default to "<name>_type" and "<name>_id".
+func (r *RelationRegistry) MorphTo(table, name, typeColumn, idColumn string) *RelationRegistry {
+	if typeColumn == "" {
+		typeColumn = name + "_type"
+	}
+
+	if idColumn == "" {
+		idColumn = name + "_id"
+	}
+
+	return r.Register(Relation{
+		Kind:       RelationMorphTo,
+		Table:      table,
+		Name:       name,
+		ForeignKey: idColumn,
+		MorphType:  typeColumn,
+	})
+}
+
+// MorphOne registers the inverse of a morph-to relation of related, whose rows refer to the
+// rows of table with the "<morphName>_type" and "<morphName>_id" columns. The type of the rows
+// of table is their MorphType, or the table itself when it has none.
+func (r *RelationRegistry) MorphOne(table, name, related, morphName string) *RelationRegistry {
+	return r.registerMorph(RelationMorphOne, table, name, related, morphName)
+}
+
+// MorphMany registers a relation like MorphOne whose table rows have many related rows.
+func (r *RelationRegistry) MorphMany(table, name, related, morphName string) *RelationRegistry {
+	return r.registerMorph(RelationMorphMany, table, name, related, morphName)
+}
+
+func (r *RelationRegistry) registerMorph(kind RelationKind, table, name, related, morphName string) *RelationRegistry {
+	return r.Register(Relation{
+		Kind:       kind,
+		Table:      table,
+		Name:       name,
+		Related:    related,
+		ForeignKey: morphName + "_id",
+		MorphType:  morphName + "_type",
+	})
+}
+
+// morphs returns a belongs-to relation per type of a morph-to relation, sorted by type.
+// The type of each relation is in its MorphValue.
+func (rel Relation) morphs(strategy NamingStrategy) []Relation {
+	types := make([]string, 0, len(rel.MorphTypes))
+	for morphType := range rel.MorphTypes {
+		types = append(types, morphType)
+	}
+
+	sort.Strings(types)
+
+	morphs := make([]Relation, len(types))
+	for i, morphType := range types {
+		morphs[i] = Relation{
+			Kind:       RelationBelongsTo,
+			Table:      rel.Table,
+			Name:       rel.Name,
+			Related:    rel.MorphTypes[morphType],
+			ForeignKey: rel.ForeignKey,
+			MorphType:  rel.MorphType,
+			MorphValue: morphType,
+		}.resolve(strategy)
+	}
+
+	return morphs
+}
+
+// morphCondition renders the condition matching the type column of a morph-to relation to its type.
+func (rel Relation) morphCondition() string {
+	return fmt.Sprintf("%s.%s = %s", rel.Table, rel.MorphType, quoteLiteral(rel.MorphValue))
+}
+
+// whereHasMorph filters the rows of a morph-to relation whose related row, in the table of
+// its type, matches the constraints added by f.
+func (q *SelectQuery) whereHasMorph(relation Relation, f func(q *SelectQuery), isNot, isAnd bool) *SelectQuery {
+	morphs := relation.morphs(q.namingStrategy())
+
+	subs := make([]*SelectQuery, len(morphs))
+	for i, morph := range morphs {
+		subs[i] = q.relatedQuery(morph, "1", f)
+	}
+
+	q.conditions = append(q.conditions, whereStructure{
+		isAnd: isAnd,
+		build: func(e env) (string, []any, error) {
+			var (
+				conditions []string
+				args       []any
+			)
+
+			for i, sub := range subs {
+				query, subArgs, err := sub.prepareSelectQuery(e)
+				if err != nil {
+					return "", nil, err
+				}
+
+				conditions = append(conditions, fmt.Sprintf("(%s AND EXISTS (%s))", morphs[i].morphCondition(), query))
+				args = append(args, subArgs...)
+			}
+
+			if len(conditions) == 0 {
+				conditions = []string{"1 = 0"}
+			}
+
+			query := "(" + strings.Join(conditions, " OR ") + ")"
+			if isNot {
+				query = "NOT " + query
+			}
+
+			return query, args, nil
+		},
+	})
+
+	return q
+}
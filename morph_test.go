@@ -0,0 +1,66 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func init() {
+	ququery.Relations.
+		MorphType("article", "articles").
+		MorphType("video", "videos").
+		MorphTo("remarks", "remarkable", "", "").
+		MorphMany("articles", "remarks", "remarks", "remarkable").
+		MorphOne("videos", "cover", "images", "imageable")
+}
+
+func TestSelectQuery_MorphRelations(t *testing.T) {
+	testcases := testutil.Testcases{
+		"with a morph-to relation": testutil.Testcase{
+			Query:       ququery.Select("remarks").With("remarkable").Query(),
+			ExpectedSQL: "SELECT * FROM remarks LEFT JOIN articles ON articles.id = remarks.remarkable_id AND remarks.remarkable_type = 'article' LEFT JOIN videos ON videos.id = remarks.remarkable_id AND remarks.remarkable_type = 'video'",
+			Doc:         "every type is joined with its discriminator",
+		},
+		"join a morph-many relation": testutil.Testcase{
+			Query:       ququery.Select("articles").JoinRelation("remarks").Query(),
+			ExpectedSQL: "SELECT * FROM articles INNER JOIN remarks ON remarks.remarkable_id = articles.id AND remarks.remarkable_type = 'article'",
+			Doc:         "the type of the table comes from the morph types",
+		},
+		"with a morph-one relation": testutil.Testcase{
+			Query:       ququery.Select("videos").With("cover").Query(),
+			ExpectedSQL: "SELECT * FROM videos LEFT JOIN images ON images.imageable_id = videos.id AND images.imageable_type = 'video'",
+			Doc:         "morph-one relations join like has-one relations",
+		},
+		"where has a morph-many relation": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM articles WHERE EXISTS (SELECT 1 FROM remarks WHERE remarks.remarkable_id = articles.id AND remarks.remarkable_type = 'article' AND (approved = $1))",
+			ExpectedArgs: []any{true},
+			Doc:          "existence filters add the discriminator",
+		}.Build(ququery.Select("articles").WhereHas("remarks", func(q *ququery.SelectQuery) {
+			q.Where("approved")
+		}), true),
+		"where has a morph-to relation": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM remarks WHERE approved = $1 AND ((remarks.remarkable_type = 'article' AND EXISTS (SELECT 1 FROM articles WHERE articles.id = remarks.remarkable_id AND (published = $2))) OR (remarks.remarkable_type = 'video' AND EXISTS (SELECT 1 FROM videos WHERE videos.id = remarks.remarkable_id AND (published = $3))))",
+			ExpectedArgs: []any{true, true, true},
+			Doc:          "the constraints apply to every type",
+		}.Build(ququery.Select("remarks").Where("approved").WhereHas("remarkable", func(q *ququery.SelectQuery) {
+			q.Where("published")
+		}), true, true, true),
+		"where doesn't have a morph-to relation": testutil.Testcase{
+			Query:       ququery.Select("remarks").WhereDoesntHave("remarkable", nil).Query(),
+			ExpectedSQL: "SELECT * FROM remarks WHERE NOT ((remarks.remarkable_type = 'article' AND EXISTS (SELECT 1 FROM articles WHERE articles.id = remarks.remarkable_id)) OR (remarks.remarkable_type = 'video' AND EXISTS (SELECT 1 FROM videos WHERE videos.id = remarks.remarkable_id)))",
+			Doc:         "rows whose related row is missing",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	if _, _, err := ququery.Select("remarks").JoinRelation("remarkable").Build(); err == nil {
+		t.Error("expected an error for an inner join on a morph-to relation")
+	}
+
+	if _, _, err := ququery.Select("remarks").WhereHasCount("remarkable", ">", 0).Build(); err == nil {
+		t.Error("expected an error for counting a morph-to relation")
+	}
+}
@@ -1,41 +1,25 @@
 package ququery
 
-import "fmt"
-
+// MultiWhere holds the conditions of a where group. It has every condition
+// method of WhereContainer, including nested groups.
 type MultiWhere struct {
-	wheres []whereStructure
+	WhereContainer[*MultiWhere]
 }
 
-func (w MultiWhere) Where(condition ...string) MultiWhere {
-	operator := "="
-	if len(condition) > 1 {
-		operator = condition[1]
-	}
-
-	w.wheres = append(w.wheres, whereStructure{
-		column:   condition[0],
-		operator: operator,
-		isAnd:    true,
-	})
+func newMultiWhere(dialect Dialect) *MultiWhere {
+	w := &MultiWhere{}
+	w.WhereContainer = WhereContainer[*MultiWhere]{self: w, dialect: dialect}
 
 	return w
 }
 
-func (w MultiWhere) OrWhere(condition ...string) MultiWhere {
-	operator := "="
-	if len(condition) > 1 {
-		operator = condition[1]
+// Query returns the group's conditions wrapped in parentheses, or an empty
+// string when they can't be built.
+func (w *MultiWhere) Query() string {
+	query, _, err := prepareConditions(w.conditions, env{dialect: w.dialect})
+	if err != nil {
+		return ""
 	}
 
-	w.wheres = append(w.wheres, whereStructure{
-		column:   condition[0],
-		operator: operator,
-		isAnd:    false,
-	})
-
-	return w
-}
-
-func (w MultiWhere) Query() string {
-	return fmt.Sprintf("(%s)", prepareMultiWhereConditions(w.wheres))
+	return "(" + query + ")"
 }
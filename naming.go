@@ -0,0 +1,238 @@
+package ququery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NamingStrategy derives the table and key names of a relation from its entity name,
+// like "roles" and "role_id" for the "role" entity.
+type NamingStrategy interface {
+	// Table returns the table of an entity.
+	Table(entity string) string
+
+	// Entity returns the entity of a table, the inverse of Table.
+	Entity(table string) string
+
+	// ForeignKey returns the column that refers to an entity.
+	ForeignKey(entity string) string
+
+	// PrimaryKey returns the primary key of a table.
+	PrimaryKey(table string) string
+}
+
+// Naming is the default NamingStrategy. Its zero value pluralizes English entity names,
+// uses "<entity>_id" foreign keys and "id" primary keys.
+type Naming struct {
+	// Irregular maps singular entity names to their tables, taking precedence over the plural rules.
+	// When entities share a table, Entity returns the first of them in sorted order.
+	Irregular map[string]string
+
+	// SingularTables uses entity names as table names.
+	SingularTables bool
+
+	// ForeignKeyFormat formats the foreign key of an entity. It defaults to "%s_id".
+	ForeignKeyFormat string
+
+	// PrimaryKeyName is the primary key of every table. It defaults to "id".
+	PrimaryKeyName string
+}
+
+var (
+	namingMu sync.RWMutex
+	naming   NamingStrategy = Naming{}
+)
+
+// SetNamingStrategy changes the naming strategy of builders that don't set their own.
+//
+// Example:
+//
+//	ququery.SetNamingStrategy(ququery.Naming{SingularTables: true, ForeignKeyFormat: "%sId"})
+//	query := ququery.Select("user").With("role").Query()
+//	log.Println(query) => SELECT * FROM user LEFT JOIN role ON role.id = user.roleId
+func SetNamingStrategy(strategy NamingStrategy) {
+	namingMu.Lock()
+	defer namingMu.Unlock()
+
+	naming = strategy
+}
+
+func defaultNaming() NamingStrategy {
+	namingMu.RLock()
+	defer namingMu.RUnlock()
+
+	return naming
+}
+
+// irregularPlurals are the English nouns the plural rules get wrong.
+var irregularPlurals = map[string]string{
+	"person":      "people",
+	"man":         "men",
+	"woman":       "women",
+	"child":       "children",
+	"tooth":       "teeth",
+	"foot":        "feet",
+	"mouse":       "mice",
+	"goose":       "geese",
+	"ox":          "oxen",
+	"leaf":        "leaves",
+	"life":        "lives",
+	"knife":       "knives",
+	"wife":        "wives",
+	"half":        "halves",
+	"shelf":       "shelves",
+	"wolf":        "wolves",
+	"hero":        "heroes",
+	"potato":      "potatoes",
+	"tomato":      "tomatoes",
+	"criterion":   "criteria",
+	"datum":       "data",
+	"medium":      "media",
+	"sheep":       "sheep",
+	"fish":        "fish",
+	"series":      "series",
+	"species":     "species",
+	"news":        "news",
+	"equipment":   "equipment",
+	"information": "information",
+	"metadata":    "metadata",
+}
+
+// Table returns the plural of the entity. Only the last word of a snake_case entity
+// is pluralized, so "category_history" becomes "category_histories".
+func (n Naming) Table(entity string) string {
+	if n.SingularTables {
+		return entity
+	}
+
+	if table, ok := n.Irregular[entity]; ok {
+		return table
+	}
+
+	prefix, word := "", entity
+	if i := strings.LastIndex(entity, "_"); i >= 0 {
+		prefix, word = entity[:i+1], entity[i+1:]
+	}
+
+	if table, ok := n.Irregular[word]; ok {
+		return prefix + table
+	}
+
+	return prefix + pluralize(word)
+}
+
+// Entity returns the singular of the table, the inverse of Table.
+func (n Naming) Entity(table string) string {
+	if n.SingularTables {
+		return table
+	}
+
+	entities := make([]string, 0, len(n.Irregular))
+	for entity := range n.Irregular {
+		entities = append(entities, entity)
+	}
+
+	// Sorted, so entities sharing a table always give the same one.
+	sort.Strings(entities)
+
+	for _, entity := range entities {
+		if n.Irregular[entity] == table {
+			return entity
+		}
+	}
+
+	prefix, word := "", table
+	if i := strings.LastIndex(table, "_"); i >= 0 {
+		prefix, word = table[:i+1], table[i+1:]
+	}
+
+	for _, entity := range entities {
+		if n.Irregular[entity] == word {
+			return prefix + entity
+		}
+	}
+
+	return prefix + singularize(word)
+}
+
+// ForeignKey returns the foreign key of the entity.
+func (n Naming) ForeignKey(entity string) string {
+	if n.ForeignKeyFormat == "" {
+		return entity + "_id"
+	}
+
+	return fmt.Sprintf(n.ForeignKeyFormat, entity)
+}
+
+// PrimaryKey returns the primary key of the table.
+func (n Naming) PrimaryKey(string) string {
+	if n.PrimaryKeyName == "" {
+		return "id"
+	}
+
+	return n.PrimaryKeyName
+}
+
+// singularPlurals maps the irregular plurals back to their singular.
+var singularPlurals = func() map[string]string {
+	singulars := make(map[string]string, len(irregularPlurals))
+	for singular, plural := range irregularPlurals {
+		singulars[plural] = singular
+	}
+
+	return singulars
+}()
+
+func singularize(word string) string {
+	lower := strings.ToLower(word)
+
+	if singular, ok := singularPlurals[lower]; ok {
+		return word[:1] + singular[1:]
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "yses"):
+		return word[:len(word)-2] + "is"
+	case strings.HasSuffix(lower, "ies") && len(lower) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "zes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "uses") && !strings.HasSuffix(lower, "ouses") && !strings.HasSuffix(lower, "auses"):
+		// "buses" and "statuses", but not "houses" and "causes", whose singular ends in "e".
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "ss"):
+		return word
+	case strings.HasSuffix(lower, "s"):
+		return word[:len(word)-1]
+	}
+
+	return word
+}
+
+func pluralize(word string) string {
+	if word == "" {
+		return word
+	}
+
+	lower := strings.ToLower(word)
+
+	if plural, ok := irregularPlurals[lower]; ok {
+		// Keep the case of the first letter, like "Person" and "People".
+		return word[:1] + plural[1:]
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "sis"):
+		return word[:len(word)-2] + "es"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return word + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+
+	return word + "s"
+}
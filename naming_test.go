@@ -0,0 +1,87 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestNaming_Table(t *testing.T) {
+	naming := ququery.Naming{Irregular: map[string]string{"staff": "staff", "employee": "staff", "member": "staff"}}
+
+	tables := map[string]string{
+		"role":             "roles",
+		"city":             "cities",
+		"key":              "keys",
+		"person":           "people",
+		"status":           "statuses",
+		"address":          "addresses",
+		"box":              "boxes",
+		"branch":           "branches",
+		"analysis":         "analyses",
+		"category_history": "category_histories",
+		"user_child":       "user_children",
+		"staff":            "staff",
+		"news":             "news",
+	}
+
+	for entity, expected := range tables {
+		if table := naming.Table(entity); table != expected {
+			t.Errorf("table of %q: expected %q, got %q", entity, expected, table)
+		}
+	}
+}
+
+func TestSelectQuery_WithNaming(t *testing.T) {
+	singular := ququery.Naming{SingularTables: true, ForeignKeyFormat: "%sId", PrimaryKeyName: "uid"}
+
+	testcases := testutil.Testcases{
+		"irregular plurals": testutil.Testcase{
+			Query:       ququery.Select("users").With("person", "status").Query(),
+			ExpectedSQL: "SELECT * FROM users LEFT JOIN people ON people.id = users.person_id LEFT JOIN statuses ON statuses.id = users.status_id",
+			Doc:         "the default naming pluralizes english nouns",
+		},
+		"custom naming strategy": testutil.Testcase{
+			Query:       ququery.Select("user").Naming(singular).With("role").Query(),
+			ExpectedSQL: "SELECT * FROM user LEFT JOIN role ON role.uid = user.roleId",
+			Doc:         "singular tables with custom keys",
+		},
+		"relation with overrides": testutil.Testcase{
+			Query:       ququery.Select("posts").WithRelation("author", "users", "written_by", "").WithRelation("category", "", "", "code").Query(),
+			ExpectedSQL: "SELECT * FROM posts LEFT JOIN users ON users.id = posts.written_by LEFT JOIN categories ON categories.code = posts.category_id",
+			Doc:         "empty names come from the naming strategy",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestNaming_Entity(t *testing.T) {
+	naming := ququery.Naming{Irregular: map[string]string{"staff": "staff", "employee": "staff", "member": "staff"}}
+
+	entities := map[string]string{
+		"roles":              "role",
+		"cities":             "city",
+		"keys":               "key",
+		"people":             "person",
+		"statuses":           "status",
+		"addresses":          "address",
+		"boxes":              "box",
+		"branches":           "branch",
+		"analyses":           "analysis",
+		"houses":             "house",
+		"causes":             "cause",
+		"buses":              "bus",
+		"bonuses":            "bonus",
+		"category_histories": "category_history",
+		"staff":              "employee",
+		"team_staff":         "team_employee",
+	}
+
+	for table, expected := range entities {
+		if entity := naming.Entity(table); entity != expected {
+			t.Errorf("entity of %q: expected %q, got %q", table, expected, entity)
+		}
+	}
+}
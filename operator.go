@@ -0,0 +1,97 @@
+package ququery
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	operatorsMu sync.RWMutex
+
+	// operators holds the allowed operators and the dialects that support them.
+	// Operators without dialects are supported everywhere.
+	operators = map[string][]Dialect{
+		"=":                    nil,
+		"!=":                   nil,
+		"<>":                   nil,
+		">":                    nil,
+		"<":                    nil,
+		">=":                   nil,
+		"<=":                   nil,
+		"NOT":                  nil,
+		"LIKE":                 nil,
+		"NOT LIKE":             nil,
+		"IS DISTINCT FROM":     nil,
+		"IS NOT DISTINCT FROM": nil,
+		"ILIKE":                {PostgreSQL},
+		"NOT ILIKE":            {PostgreSQL},
+		"~":                    {PostgreSQL},
+		"~*":                   {PostgreSQL},
+		"!~":                   {PostgreSQL},
+		"!~*":                  {PostgreSQL},
+		"REGEXP":               {MySQL, SQLite},
+		"NOT REGEXP":           {MySQL, SQLite},
+		"@>":                   {PostgreSQL},
+		"<@":                   {PostgreSQL},
+		"&&":                   {PostgreSQL},
+	}
+)
+
+// RegisterOperator allows op to be used in where clauses. When dialects are
+// given, op is only allowed on those dialects.
+//
+// Example:
+//
+//	ququery.RegisterOperator("%", ququery.PostgreSQL) // pg_trgm similarity
+//	query := ququery.Select("users").Where("name", "%").Query()
+//	log.Println(query) => SELECT * FROM users WHERE name % $1
+func RegisterOperator(op string, dialects ...Dialect) {
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+
+	operators[normalizeOperator(op)] = dialects
+}
+
+// normalizeOperator upper cases op and collapses its spaces, so "is  distinct from"
+// and "IS DISTINCT FROM" are the same operator.
+func normalizeOperator(op string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(op), " "))
+}
+
+// compare renders "column op ?" after checking op is allowed on the dialect.
+func compare(dialect Dialect, column, op string) (string, error) {
+	operatorsMu.RLock()
+	dialects, ok := operators[op]
+	operatorsMu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownOperator, op)
+	}
+
+	if len(dialects) > 0 && !supports(dialects, dialect) {
+		return "", fmt.Errorf("%w: operator %s on %s", ErrUnsupported, op, dialect)
+	}
+
+	// MySQL spells null-safe comparison with the <=> operator.
+	if dialect == MySQL {
+		switch op {
+		case "IS NOT DISTINCT FROM":
+			return column + " <=> ?", nil
+		case "IS DISTINCT FROM":
+			return "NOT " + column + " <=> ?", nil
+		}
+	}
+
+	return column + " " + op + " ?", nil
+}
+
+func supports(dialects []Dialect, dialect Dialect) bool {
+	for _, d := range dialects {
+		if d == dialect {
+			return true
+		}
+	}
+
+	return false
+}
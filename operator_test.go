@@ -0,0 +1,74 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestWhereContainer_Operators(t *testing.T) {
+	ququery.RegisterOperator("%", ququery.PostgreSQL)
+
+	testcases := testutil.Testcases{
+		"where with not equal operator": {
+			Query:       ququery.Select("users").Where("name", "<>").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE name <> $1",
+			Doc:         "select users where name is not x",
+		},
+		"where with distinct operators": {
+			Query: ququery.Select("users").
+				Where("manager_id", "is distinct from").
+				OrWhere("team_id", "IS NOT DISTINCT FROM").
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE manager_id IS DISTINCT FROM $1 OR team_id IS NOT DISTINCT FROM $2",
+			Doc:         "operators are case-insensitive",
+		},
+		"where with distinct operators on mysql": {
+			Query: ququery.Select("users").
+				Dialect(ququery.MySQL).
+				Where("manager_id", "IS DISTINCT FROM").
+				OrWhere("team_id", "IS NOT DISTINCT FROM").
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE NOT manager_id <=> ? OR team_id <=> ?",
+			Doc:         "null-safe comparison uses <=> on mysql",
+		},
+		"where with regex and array operators on postgresql": {
+			Query: ququery.Select("posts").
+				Where("title", "~*").
+				Where("tags", "@>").
+				OrWhere("tags", "&&").
+				Query(),
+			ExpectedSQL: "SELECT * FROM posts WHERE title ~* $1 AND tags @> $2 OR tags && $3",
+			Doc:         "select posts by title pattern and tags",
+		},
+		"where with regexp on mysql": {
+			Query:       ququery.Select("posts").Dialect(ququery.MySQL).Where("title", "regexp").Query(),
+			ExpectedSQL: "SELECT * FROM posts WHERE title REGEXP ?",
+			Doc:         "select posts by title pattern on mysql",
+		},
+		"where with registered operator": {
+			Query:       ququery.Select("users").Where("name", "%").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE name % $1",
+			Doc:         "select users with a name similar to x",
+		},
+		"where with unknown operator": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnknownOperator,
+			Doc:         "unknown operators fail the build",
+		}.Build(ququery.Select("users").Where("name", "=="), "x"),
+		"where with operator unsupported by dialect": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "array operators are only supported on postgresql",
+		}.Build(ququery.Select("posts").Dialect(ququery.MySQL).Where("tags", "@>"), "x"),
+		"registered operator unsupported by dialect": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "registered operators are gated by their dialects",
+		}.Build(ququery.Select("users").Dialect(ququery.SQLite).Where("name", "%"), "x"),
+		"json condition with unknown operator": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnknownOperator,
+			Doc:         "json conditions validate their operators too",
+		}.Build(ququery.Select("users").WhereJSON("meta->theme", "=~"), "x"),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
@@ -0,0 +1,276 @@
+package ququery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PivotTable builds the statements maintaining the rows of a many-to-many pivot table.
+type PivotTable struct {
+	table      string
+	foreignKey string
+	relatedKey string
+	columns    []string
+	dialect    Dialect
+	ctx        context.Context
+}
+
+// PivotRow is a related ID to attach with values for the extra columns of the pivot table.
+type PivotRow struct {
+	ID     any
+	Values map[string]any
+}
+
+// Pivot returns the pivot table whose foreignKey refers to the parent rows and
+// relatedKey refers to the related rows.
+//
+// Example:
+//
+//	roles := ququery.Pivot("role_user", "user_id", "role_id")
+//	query, args, err := roles.Attach(7, 1, 2).Build()
+//	log.Println(query, args) => INSERT INTO role_user (user_id, role_id) VALUES ($1, $2), ($3, $4) ON CONFLICT DO NOTHING [7 1 7 2]
+func Pivot(table, foreignKey, relatedKey string) *PivotTable {
+	return &PivotTable{table: table, foreignKey: foreignKey, relatedKey: relatedKey}
+}
+
+// Dialect sets the dialect of the statements of the pivot table.
+func (p *PivotTable) Dialect(dialect Dialect) *PivotTable {
+	p.dialect = dialect
+
+	return p
+}
+
+// Context sets the context of the statements of the pivot table. When the pivot table is
+// registered with TenantTables, attach sets the tenant column to the tenant of the context
+// and detach and sync only delete the rows of the tenant.
+//
+// Example:
+//
+//	ctx = ququery.WithTenant(ctx, 5)
+//	query, args, err := ququery.Pivot("project_user", "user_id", "project_id").Context(ctx).Attach(7, 1).Build()
+//	log.Println(query, args) => INSERT INTO project_user (user_id, project_id, tenant_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING [7 1 5]
+func (p *PivotTable) Context(ctx context.Context) *PivotTable {
+	p.ctx = ctx
+
+	return p
+}
+
+// Columns sets the extra columns of the pivot table filled by Attach and Sync.
+func (p *PivotTable) Columns(columns ...string) *PivotTable {
+	p.columns = columns
+
+	return p
+}
+
+// Attach returns the statement adding the pivot rows of parent and the related IDs that don't exist yet.
+// A related ID can be a PivotRow to fill the extra columns, which are NULL otherwise.
+//
+// Example:
+//
+//	query, args, err := ququery.Pivot("role_user", "user_id", "role_id").
+//		Columns("granted_by").
+//		Attach(7, ququery.PivotRow{ID: 1, Values: map[string]any{"granted_by": 3}}).
+//		Build()
+//
+//	log.Println(query, args) => INSERT INTO role_user (user_id, role_id, granted_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING [7 1 3]
+func (p *PivotTable) Attach(parent any, related ...any) *AttachQuery {
+	rows := make([]PivotRow, len(related))
+
+	for i, r := range related {
+		if row, ok := r.(PivotRow); ok {
+			rows[i] = row
+		} else {
+			rows[i] = PivotRow{ID: r}
+		}
+	}
+
+	return &AttachQuery{pivot: *p, parent: parent, rows: rows}
+}
+
+// Detach returns the statement deleting the pivot rows of parent and the related IDs,
+// or every pivot row of parent when no ID is given.
+//
+// Example:
+//
+//	query, args, err := ququery.Pivot("role_user", "user_id", "role_id").Detach(7, 1, 2).Build()
+//	log.Println(query, args) => DELETE FROM role_user WHERE user_id = $1 AND role_id IN ($2, $3) [7 1 2]
+func (p *PivotTable) Detach(parent any, related ...any) *DeleteQuery {
+	q := p.delete(parent)
+
+	if len(related) > 0 {
+		q.WhereIn(p.relatedKey, related...)
+	}
+
+	return q
+}
+
+// Sync returns the statements bringing the pivot rows of parent in line with the related IDs:
+// the deletion of the rows of other IDs, then the attachment of the missing ones. The attach
+// statement is nil when no ID is given. Extra columns of existing rows aren't changed.
+//
+// Example:
+//
+//	detach, attach := ququery.Pivot("role_user", "user_id", "role_id").Sync(7, 1, 2)
+//
+//	log.Println(detach.Query()) => DELETE FROM role_user WHERE user_id = $1 AND role_id NOT IN ($2, $3)
+//	log.Println(attach.Query()) => INSERT INTO role_user (user_id, role_id) VALUES ($1, $2), ($3, $4) ON CONFLICT DO NOTHING
+func (p *PivotTable) Sync(parent any, related ...any) (*DeleteQuery, *AttachQuery) {
+	if len(related) == 0 {
+		return p.delete(parent), nil
+	}
+
+	attach := p.Attach(parent, related...)
+
+	ids := make([]any, len(attach.rows))
+	for i, row := range attach.rows {
+		ids[i] = row.ID
+	}
+
+	return p.delete(parent).WhereNotIn(p.relatedKey, ids...), attach
+}
+
+func (p *PivotTable) delete(parent any) *DeleteQuery {
+	return Delete(p.table).Dialect(p.dialect).Context(p.ctx).whereValue(p.foreignKey, "=", parent, true)
+}
+
+// AttachQuery inserts pivot rows, skipping the ones that exist.
+type AttachQuery struct {
+	pivot  PivotTable
+	parent any
+	rows   []PivotRow
+}
+
+// Query returns the SQL of the query, or an empty string when the query can't be built.
+//
+// Deprecated: Query hides build errors, and some drivers run an empty query as a no-op.
+// Use Build, which returns the build error and the bound arguments.
+func (q *AttachQuery) Query() string {
+	query, _, err := q.build()
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// Build returns the SQL of the query with its arguments in placeholder order.
+func (q *AttachQuery) Build(values ...any) (string, []any, error) {
+	query, args, err := q.build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args, err = bindArgs(args, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
+}
+
+func (q *AttachQuery) build() (string, []any, error) {
+	p := q.pivot
+
+	if len(q.rows) == 0 {
+		return "", nil, fmt.Errorf("ququery: nothing to attach to %s", p.table)
+	}
+
+	columns := append([]string{p.foreignKey, p.relatedKey}, p.columns...)
+
+	tenantColumn, tenant, err := insertTenant(p.ctx, p.table, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if tenantColumn != "" {
+		columns = append(columns, tenantColumn)
+	}
+
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	rows := make([]string, len(q.rows))
+	args := make([]any, 0, len(q.rows)*len(columns))
+
+	for i, r := range q.rows {
+		if err := p.checkValues(r.Values); err != nil {
+			return "", nil, err
+		}
+
+		rows[i] = row
+		args = append(args, q.parent, r.ID)
+
+		for _, column := range p.columns {
+			args = append(args, r.Values[column])
+		}
+
+		if tenantColumn != "" {
+			args = append(args, tenant)
+		}
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", p.table, strings.Join(columns, ", "), strings.Join(rows, ", "))
+
+	if p.dialect == MySQL {
+		query += fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", p.foreignKey, p.foreignKey)
+	} else {
+		query += " ON CONFLICT DO NOTHING"
+	}
+
+	return sqlx.Rebind(p.dialect.bindType(), query), args, nil
+}
+
+// checkValues reports the values of a pivot row that aren't extra columns of the pivot table.
+func (p PivotTable) checkValues(values map[string]any) error {
+	var unknown []string
+
+	for column := range values {
+		found := false
+
+		for _, c := range p.columns {
+			if c == column {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			unknown = append(unknown, column)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+
+		return fmt.Errorf("ququery: %s has no extra columns %s", p.table, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// JoinPivot method used to add inner joins from table to related through their pivot table.
+// The keys come from the naming strategy, like "role_user.user_id" and "role_user.role_id".
+//
+// Example:
+//
+//	query := ququery.Select("users").JoinPivot("users", "roles", "role_user").Query()
+//	log.Println(query) => SELECT * FROM users INNER JOIN role_user ON role_user.user_id = users.id INNER JOIN roles ON roles.id = role_user.role_id
+func (q *SelectQuery) JoinPivot(table, related, pivot string) *SelectQuery {
+	strategy := q.namingStrategy()
+
+	relation := Relation{
+		Kind:            RelationManyToMany,
+		Table:           table,
+		Related:         related,
+		Pivot:           pivot,
+		ForeignKey:      strategy.ForeignKey(strategy.Entity(table)),
+		RelatedPivotKey: strategy.ForeignKey(strategy.Entity(related)),
+	}
+
+	q.joinResolved(relation.resolve(strategy), innerJoin)
+
+	return q
+}
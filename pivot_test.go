@@ -0,0 +1,74 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func TestPivot(t *testing.T) {
+	roles := ququery.Pivot("role_user", "user_id", "role_id")
+	tags := ququery.Pivot("post_tag", "post_id", "tag_id").Dialect(ququery.MySQL).Columns("added_by", "position")
+
+	detach, attach := ququery.Pivot("role_user", "user_id", "role_id").Sync(7, 1, 2)
+	detachAll, noAttach := ququery.Pivot("role_user", "user_id", "role_id").Sync(7)
+
+	if noAttach != nil {
+		t.Error("expected no attach statement when syncing an empty set")
+	}
+
+	testcases := testutil.Testcases{
+		"attach related ids": testutil.Testcase{
+			ExpectedSQL:  "INSERT INTO role_user (user_id, role_id) VALUES ($1, $2), ($3, $4) ON CONFLICT DO NOTHING",
+			ExpectedArgs: []any{7, 1, 7, 2},
+			Doc:          "existing pivot rows are skipped",
+		}.Build(roles.Attach(7, 1, 2)),
+		"attach with extra columns on mysql": testutil.Testcase{
+			ExpectedSQL:  "INSERT INTO post_tag (post_id, tag_id, added_by, position) VALUES (?, ?, ?, ?), (?, ?, ?, ?) ON DUPLICATE KEY UPDATE post_id = post_id",
+			ExpectedArgs: []any{3, 10, 5, 1, 3, 11, nil, nil},
+			Doc:          "missing extra values are null",
+		}.Build(tags.Attach(3, ququery.PivotRow{ID: 10, Values: map[string]any{"added_by": 5, "position": 1}}, 11)),
+		"detach related ids": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM role_user WHERE user_id = $1 AND role_id IN ($2, $3)",
+			ExpectedArgs: []any{7, 1, 2},
+			Doc:          "delete the pivot rows of the ids",
+		}.Build(roles.Detach(7, 1, 2)),
+		"detach every related id": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM role_user WHERE user_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "delete every pivot row of the parent",
+		}.Build(roles.Detach(7)),
+		"sync deletes other ids": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM role_user WHERE user_id = $1 AND role_id NOT IN ($2, $3)",
+			ExpectedArgs: []any{7, 1, 2},
+			Doc:          "the first sync statement",
+		}.Build(detach),
+		"sync attaches missing ids": testutil.Testcase{
+			ExpectedSQL:  "INSERT INTO role_user (user_id, role_id) VALUES ($1, $2), ($3, $4) ON CONFLICT DO NOTHING",
+			ExpectedArgs: []any{7, 1, 7, 2},
+			Doc:          "the second sync statement",
+		}.Build(attach),
+		"sync an empty set": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM role_user WHERE user_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "syncing nothing detaches everything",
+		}.Build(detachAll),
+		"join through the pivot table": testutil.Testcase{
+			Query:       ququery.Select("users").JoinPivot("users", "roles", "role_user").Query(),
+			ExpectedSQL: "SELECT * FROM users INNER JOIN role_user ON role_user.user_id = users.id INNER JOIN roles ON roles.id = role_user.role_id",
+			Doc:         "keys come from the naming strategy",
+		},
+		"join irregular tables through the pivot table": testutil.Testcase{
+			Query:       ququery.Select("people").JoinPivot("people", "categories", "category_person").Query(),
+			ExpectedSQL: "SELECT * FROM people INNER JOIN category_person ON category_person.person_id = people.id INNER JOIN categories ON categories.id = category_person.category_id",
+			Doc:         "tables are singularized for the keys",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	if _, _, err := tags.Attach(3, ququery.PivotRow{ID: 10, Values: map[string]any{"role": 1}}).Build(); err == nil {
+		t.Error("expected an error for an unknown extra column")
+	}
+}
@@ -0,0 +1,564 @@
+package ququery
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// RelationKind is the kind of a registered relation.
+type RelationKind int
+
+const (
+	RelationBelongsTo RelationKind = iota
+	RelationHasOne
+	RelationHasMany
+	RelationManyToMany
+	RelationMorphTo
+	RelationMorphOne
+	RelationMorphMany
+)
+
+// Relation describes how the rows of Table relate to the rows of Related.
+type Relation struct {
+	Kind    RelationKind
+	Table   string
+	Name    string
+	Related string
+
+	// ForeignKey is the column of Table referring to Related for belongs-to and morph-to
+	// relations, the column of Related referring to Table for has-one, has-many, morph-one
+	// and morph-many relations, and the column of Pivot referring to Table for many-to-many relations.
+	ForeignKey string
+
+	// OwnerKey is the column of Related the foreign key of a belongs-to relation refers to.
+	OwnerKey string
+
+	// LocalKey is the column of Table referred to by has-one, has-many, morph-one, morph-many
+	// and many-to-many relations.
+	LocalKey string
+
+	// Pivot is the table joining the two sides of a many-to-many relation.
+	Pivot string
+
+	// RelatedPivotKey is the column of Pivot referring to Related.
+	RelatedPivotKey string
+
+	// RelatedKey is the column of Related referred to by RelatedPivotKey.
+	RelatedKey string
+
+	// MorphType is the column holding the type of the rows the foreign key refers to in
+	// polymorphic relations. It is a column of Table for morph-to relations and of Related otherwise.
+	MorphType string
+
+	// MorphValue is the type of the rows of Table in the MorphType column of morph-one and
+	// morph-many relations.
+	MorphValue string
+
+	// MorphTypes maps the types of a morph-to relation to their tables.
+	MorphTypes map[string]string
+}
+
+// RelationRegistry holds the relations of every table by name.
+// It is safe for concurrent use.
+type RelationRegistry struct {
+	mu         sync.RWMutex
+	relations  map[string]map[string]Relation
+	morphTypes map[string]string
+}
+
+// Relations is the registry used by With, JoinRelation and the relation filters.
+//
+// Example:
+//
+//	ququery.Relations.
+//		BelongsTo("users", "role", "roles", "role_id", "id").
+//		HasMany("users", "posts", "posts", "author_id", "id").
+//		ManyToMany("users", "tags", "tags", "tag_user", "user_id", "tag_id")
+var Relations = NewRelationRegistry()
+
+// NewRelationRegistry returns an empty registry.
+func NewRelationRegistry() *RelationRegistry {
+	return &RelationRegistry{relations: map[string]map[string]Relation{}, morphTypes: map[string]string{}}
+}
+
+// BelongsTo registers a relation whose foreign key is a column of table. The owner key is the
+// column of related it refers to. Empty keys come from the naming strategy of the query using it.
+func (r *RelationRegistry) BelongsTo(table, name, related, foreignKey, ownerKey string) *RelationRegistry {
+	return r.Register(Relation{
+		Kind:       RelationBelongsTo,
+		Table:      table,
+		Name:       name,
+		Related:    related,
+		ForeignKey: foreignKey,
+		OwnerKey:   ownerKey,
+	})
+}
+
+// HasOne registers a relation whose foreign key is a column of related. The local key is the
+// column of table it refers to and defaults to the primary key of table.
+func (r *RelationRegistry) HasOne(table, name, related, foreignKey, localKey string) *RelationRegistry {
+	return r.Register(Relation{
+		Kind:       RelationHasOne,
+		Table:      table,
+		Name:       name,
+		Related:    related,
+		ForeignKey: foreignKey,
+		LocalKey:   localKey,
+	})
+}
+
+// HasMany registers a relation like HasOne whose table rows have many related rows.
+func (r *RelationRegistry) HasMany(table, name, related, foreignKey, localKey string) *RelationRegistry {
+	return r.Register(Relation{
+		Kind:       RelationHasMany,
+		Table:      table,
+		Name:       name,
+		Related:    related,
+		ForeignKey: foreignKey,
+		LocalKey:   localKey,
+	})
+}
+
+// ManyToMany registers a relation through the pivot table, whose foreign key refers to table
+// and related pivot key refers to related. Both refer to the primary keys of their tables.
+func (r *RelationRegistry) ManyToMany(table, name, related, pivot, foreignKey, relatedPivotKey string) *RelationRegistry {
+	return r.Register(Relation{
+		Kind:            RelationManyToMany,
+		Table:           table,
+		Name:            name,
+		Related:         related,
+		Pivot:           pivot,
+		ForeignKey:      foreignKey,
+		RelatedPivotKey: relatedPivotKey,
+	})
+}
+
+// Register adds the relation, replacing any relation with the same table and name.
+func (r *RelationRegistry) Register(relation Relation) *RelationRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.relations[relation.Table] == nil {
+		r.relations[relation.Table] = map[string]Relation{}
+	}
+
+	r.relations[relation.Table][relation.Name] = relation
+
+	return r
+}
+
+// Lookup returns the relation of table registered with name.
+func (r *RelationRegistry) Lookup(table, name string) (Relation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	relation, ok := r.relations[table][name]
+
+	switch relation.Kind {
+	case RelationMorphTo:
+		relation.MorphTypes = make(map[string]string, len(r.morphTypes))
+		for morphType, table := range r.morphTypes {
+			relation.MorphTypes[morphType] = table
+		}
+	case RelationMorphOne, RelationMorphMany:
+		if relation.MorphValue == "" {
+			relation.MorphValue = r.morphTypeOf(table)
+		}
+	}
+
+	return relation, ok
+}
+
+// resolve fills the empty keys of the relation from the naming strategy.
+func (rel Relation) resolve(strategy NamingStrategy) Relation {
+	switch rel.Kind {
+	case RelationBelongsTo:
+		if rel.ForeignKey == "" {
+			rel.ForeignKey = strategy.ForeignKey(rel.Name)
+		}
+
+		if rel.OwnerKey == "" {
+			rel.OwnerKey = strategy.PrimaryKey(rel.Related)
+		}
+	case RelationManyToMany:
+		if rel.LocalKey == "" {
+			rel.LocalKey = strategy.PrimaryKey(rel.Table)
+		}
+
+		if rel.RelatedKey == "" {
+			rel.RelatedKey = strategy.PrimaryKey(rel.Related)
+		}
+	default:
+		if rel.LocalKey == "" {
+			rel.LocalKey = strategy.PrimaryKey(rel.Table)
+		}
+	}
+
+	return rel
+}
+
+// constraints renders the condition matching the rows of the related table to the rows of
+// table. Many-to-many relations match the pivot table, see pivotConstraints.
+func (rel Relation) constraints(table string) string {
+	switch rel.Kind {
+	case RelationBelongsTo:
+		return fmt.Sprintf("%s.%s = %s.%s", rel.Related, rel.OwnerKey, table, rel.ForeignKey)
+	case RelationManyToMany:
+		return fmt.Sprintf("%s.%s = %s.%s", rel.Pivot, rel.ForeignKey, table, rel.LocalKey)
+	case RelationMorphOne, RelationMorphMany:
+		return fmt.Sprintf("%s.%s = %s.%s AND %s.%s = %s",
+			rel.Related, rel.ForeignKey, table, rel.LocalKey, rel.Related, rel.MorphType, quoteLiteral(rel.MorphValue))
+	}
+
+	return fmt.Sprintf("%s.%s = %s.%s", rel.Related, rel.ForeignKey, table, rel.LocalKey)
+}
+
+// pivotConstraints renders the condition matching the related rows of a many-to-many relation to its pivot rows.
+func (rel Relation) pivotConstraints() string {
+	return fmt.Sprintf("%s.%s = %s.%s", rel.Related, rel.RelatedKey, rel.Pivot, rel.RelatedPivotKey)
+}
+
+// Relation returns the registered relation of the query's table with its empty keys filled
+// by the naming strategy, or the belongs-to relation the naming strategy derives from the
+// name when none is registered. This is the relation With and WhereHas use.
+func (q *SelectQuery) Relation(name string) Relation {
+	strategy := q.namingStrategy()
+
+	relation, ok := Relations.Lookup(q.table, name)
+	if !ok {
+		relation = Relation{Kind: RelationBelongsTo, Table: q.table, Name: name, Related: strategy.Table(name)}
+	}
+
+	return relation.resolve(strategy)
+}
+
+// JoinRelation method used to add inner join on a registered relation of the query's table.
+// Many-to-many relations join the pivot table and the related table. Building a query
+// with a relation that isn't registered returns ErrUnknownRelation.
+//
+// Example:
+//
+//	ququery.Relations.ManyToMany("users", "tags", "tags", "tag_user", "user_id", "tag_id")
+//
+//	query := ququery.Select("users").JoinRelation("tags").Query()
+//	log.Println(query) => SELECT * FROM users INNER JOIN tag_user ON tag_user.user_id = users.id INNER JOIN tags ON tags.id = tag_user.tag_id
+func (q *SelectQuery) JoinRelation(name string) *SelectQuery {
+	return q.joinRelation(name, innerJoin)
+}
+
+// LeftJoinRelation method used to add left join on a registered relation of the query's table.
+// It has the same signature as JoinRelation.
+func (q *SelectQuery) LeftJoinRelation(name string) *SelectQuery {
+	return q.joinRelation(name, leftJoin)
+}
+
+func (q *SelectQuery) joinRelation(name string, jType joinType) *SelectQuery {
+	relation, ok := Relations.Lookup(q.table, name)
+	if !ok {
+		q.joins = append(q.joins, join{
+			err: fmt.Errorf("%w: %s.%s", ErrUnknownRelation, q.table, name),
+		})
+
+		return q
+	}
+
+	if relation.Kind == RelationMorphTo && jType == innerJoin {
+		q.joins = append(q.joins, join{
+			err: fmt.Errorf("ququery: morph-to relation %s.%s can only be left joined", q.table, name),
+		})
+
+		return q
+	}
+
+	q.joinResolved(relation.resolve(q.namingStrategy()), jType)
+
+	return q
+}
+
+func (q *SelectQuery) joinResolved(relation Relation, jType joinType) {
+	if relation.Kind == RelationMorphTo {
+		for _, morph := range relation.morphs(q.namingStrategy()) {
+			q.joins = append(q.joins, join{
+				table:       morph.Related,
+				constraints: morph.constraints(relation.Table) + " AND " + morph.morphCondition(),
+				jType:       jType,
+			})
+		}
+
+		return
+	}
+
+	if relation.Kind == RelationManyToMany {
+		q.joins = append(q.joins,
+			join{table: relation.Pivot, constraints: relation.constraints(relation.Table), jType: jType},
+			join{table: relation.Related, constraints: relation.pivotConstraints(), jType: jType},
+		)
+
+		return
+	}
+
+	q.joins = append(q.joins, join{
+		table:       relation.Related,
+		constraints: relation.constraints(relation.Table),
+		jType:       jType,
+	})
+}
+
+// WhereHas method filters the rows that have a related row matching the constraints
+// added by f, which can be nil. The relation is found like With finds it.
+//
+// Example:
+//
+//	ququery.Relations.HasMany("users", "orders", "orders", "user_id", "id")
+//
+//	query, args, err := ququery.Select("users").WhereHas("orders", func(q *ququery.SelectQuery) {
+//		q.Where("status")
+//	}).Build("paid")
+//
+//	log.Println(query, args) => SELECT * FROM users WHERE EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id AND (status = $1)) [paid]
+func (q *SelectQuery) WhereHas(relation string, f func(q *SelectQuery)) *SelectQuery {
+	return q.whereHas(relation, f, false, true)
+}
+
+// OrWhereHas method allows you to add an "or" clause to WhereHas condition.
+func (q *SelectQuery) OrWhereHas(relation string, f func(q *SelectQuery)) *SelectQuery {
+	return q.whereHas(relation, f, false, false)
+}
+
+// WhereDoesntHave method filters the rows that have no related row matching the constraints added by f.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereDoesntHave("orders", nil).Query()
+//	log.Println(query) => SELECT * FROM users WHERE NOT EXISTS (SELECT 1 FROM orders WHERE orders.user_id = users.id)
+func (q *SelectQuery) WhereDoesntHave(relation string, f func(q *SelectQuery)) *SelectQuery {
+	return q.whereHas(relation, f, true, true)
+}
+
+// OrWhereDoesntHave method allows you to add an "or" clause to WhereDoesntHave condition.
+func (q *SelectQuery) OrWhereDoesntHave(relation string, f func(q *SelectQuery)) *SelectQuery {
+	return q.whereHas(relation, f, true, false)
+}
+
+func (q *SelectQuery) whereHas(relation string, f func(q *SelectQuery), isNot, isAnd bool) *SelectQuery {
+	rel := q.Relation(relation)
+	if rel.Kind == RelationMorphTo {
+		return q.whereHasMorph(rel, f, isNot, isAnd)
+	}
+
+	sub := q.relatedQuery(rel, "1", f)
+
+	q.conditions = append(q.conditions, whereStructure{
+		isAnd: isAnd,
+		build: func(e env) (string, []any, error) {
+			query, args, err := sub.prepareSelectQuery(e)
+			if err != nil {
+				return "", nil, err
+			}
+
+			if isNot {
+				return "NOT EXISTS (" + query + ")", args, nil
+			}
+
+			return "EXISTS (" + query + ")", args, nil
+		},
+	})
+
+	return q
+}
+
+// WhereHasCount method compares the number of related rows with count, which is bound to the query.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").WhereHasCount("orders", ">=", 3).Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id) >= $1 [3]
+func (q *SelectQuery) WhereHasCount(relation, operator string, count int) *SelectQuery {
+	rel := q.Relation(relation)
+	sub := q.relatedQuery(rel, "COUNT(*)", nil)
+	op := normalizeOperator(operator)
+
+	q.conditions = append(q.conditions, whereStructure{
+		isAnd: true,
+		build: func(e env) (string, []any, error) {
+			if rel.Kind == RelationMorphTo {
+				return "", nil, fmt.Errorf("ququery: can't count the rows of morph-to relation %s.%s", rel.Table, rel.Name)
+			}
+
+			query, args, err := sub.prepareSelectQuery(e)
+			if err != nil {
+				return "", nil, err
+			}
+
+			query, err = compare(e.dialect, "("+query+")", op)
+			if err != nil {
+				return "", nil, err
+			}
+
+			return query, append(args, count), nil
+		},
+	})
+
+	return q
+}
+
+// relatedQuery returns the subquery selecting column from the related rows of the relation.
+// The conditions added by f are grouped after the condition matching the related rows.
+func (q *SelectQuery) relatedQuery(relation Relation, column string, f func(q *SelectQuery)) *SelectQuery {
+	sub := Select(relation.Related).Columns(column)
+	sub.naming = q.naming
+
+	if relation.Kind == RelationManyToMany {
+		sub.Join(relation.Pivot, relation.pivotConstraints())
+	}
+
+	sub.conditions = append(sub.conditions, whereStructure{
+		isAnd:    true,
+		isRaw:    true,
+		rawQuery: relation.constraints(q.table),
+	})
+
+	if f != nil {
+		sub.Constrain(f)
+	}
+
+	return sub
+}
+
+// Constrain calls f with the query and wraps the conditions f adds in parentheses,
+// so "or" clauses added by f can't escape the conditions added before or after them.
+//
+// Example:
+//
+//	query := ququery.Select("posts").Where("user_id").Constrain(func(q *ququery.SelectQuery) {
+//		q.Where("published").OrWhere("pinned")
+//	}).Query()
+//
+//	log.Println(query) => SELECT * FROM posts WHERE user_id = $1 AND (published = $2 OR pinned = $3)
+func (q *SelectQuery) Constrain(f func(q *SelectQuery)) *SelectQuery {
+	n := len(q.conditions)
+	f(q)
+
+	if len(q.conditions) > n {
+		added := q.conditions[n:]
+		q.conditions = append(q.conditions[:n:n], whereStructure{isAnd: true, group: added})
+	}
+
+	return q
+}
+
+// WithJSON adds a column, named after the relation, holding the related rows as JSON, so they
+// are fetched in the same query: a JSON array for has-many and many-to-many relations, and an
+// object or NULL for the others. f, which can be nil, can filter, sort and limit the related rows.
+//
+// On PostgreSQL every column of the related rows is included unless f selects others, and the
+// array keeps the order of f's OrderBy columns, which must be selected. MySQL needs f to select the
+// columns; their names or aliases are the keys of the JSON objects. JSON_ARRAYAGG doesn't keep an
+// order on MySQL, so sort the array after fetching it. SQLite isn't supported.
+//
+// Example:
+//
+//	ququery.Relations.HasMany("users", "posts", "posts", "user_id", "id")
+//
+//	query, args, err := ququery.Select("users").WithJSON("posts", func(q *ququery.SelectQuery) {
+//		q.OrderBy("created_at", ququery.DESC).Paginate(1, 5)
+//	}).Build()
+//
+//	log.Println(query, args) => SELECT *, (SELECT COALESCE(json_agg(row_to_json(posts) ORDER BY posts.created_at DESC), '[]'::json) FROM (SELECT * FROM posts WHERE posts.user_id = users.id ORDER BY created_at DESC LIMIT $1 OFFSET $2) AS posts) AS posts FROM users [5 0]
+func (q *SelectQuery) WithJSON(relation string, f func(q *SelectQuery)) *SelectQuery {
+	rel := q.Relation(relation)
+
+	column := "*"
+	if rel.Kind == RelationManyToMany {
+		column = rel.Related + ".*"
+	}
+
+	sub := q.relatedQuery(rel, column, f)
+	many := rel.Kind == RelationHasMany || rel.Kind == RelationManyToMany || rel.Kind == RelationMorphMany
+
+	if len(q.columns) == 0 {
+		q.columns = []string{"*"}
+	}
+
+	q.subColumns = append(q.subColumns, subColumn{
+		query: sub,
+		alias: rel.Name,
+		aggregate: func(dialect Dialect) (string, error) {
+			if rel.Kind == RelationMorphTo {
+				return "", fmt.Errorf("ququery: morph-to relation %s.%s can't be loaded as JSON", rel.Table, rel.Name)
+			}
+
+			switch dialect {
+			case PostgreSQL:
+				if many {
+					return fmt.Sprintf("COALESCE(json_agg(row_to_json(%s)%s), '[]'::json)", rel.Name, aggregateOrder(rel.Name, sub.orderBy)), nil
+				}
+
+				return fmt.Sprintf("row_to_json(%s)", rel.Name), nil
+			case MySQL:
+				object, err := jsonObject(rel.Name, sub.columns)
+				if err != nil {
+					return "", err
+				}
+
+				if many {
+					return fmt.Sprintf("COALESCE(JSON_ARRAYAGG(%s), JSON_ARRAY())", object), nil
+				}
+
+				return object, nil
+			}
+
+			return "", fmt.Errorf("%w: JSON relations on %s", ErrUnsupported, dialect)
+		},
+	})
+
+	return q
+}
+
+// aggregateOrder renders the ORDER BY of an aggregate over the derived table alias, sorting by
+// the orders of its query. Qualified columns are referred to through the alias.
+func aggregateOrder(alias string, orders []order) string {
+	if len(orders) == 0 {
+		return ""
+	}
+
+	columns := make([]order, len(orders))
+	for i, o := range orders {
+		column := o.column
+		if j := strings.LastIndex(column, "."); j >= 0 && jsonKey.MatchString(column[j+1:]) {
+			column = column[j+1:]
+		}
+
+		if jsonKey.MatchString(column) {
+			column = alias + "." + column
+		}
+
+		columns[i] = order{column: column, direction: o.direction}
+	}
+
+	return " ORDER BY " + prepareOrderByQuery(columns)
+}
+
+// jsonObject renders a MySQL JSON_OBJECT of the columns of the derived table alias.
+func jsonObject(alias string, columns []string) (string, error) {
+	pairs := make([]string, len(columns))
+
+	for i, column := range columns {
+		name := column
+		if j := strings.LastIndex(strings.ToUpper(column), " AS "); j >= 0 {
+			name = column[j+4:]
+		} else if j := strings.LastIndex(column, "."); j >= 0 {
+			name = column[j+1:]
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "*" {
+			return "", fmt.Errorf("ququery: JSON relation %s needs its columns on MySQL", alias)
+		}
+
+		pairs[i] = fmt.Sprintf("%s, %s.%s", quoteLiteral(name), alias, name)
+	}
+
+	return fmt.Sprintf("JSON_OBJECT(%s)", strings.Join(pairs, ", ")), nil
+}
@@ -0,0 +1,118 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func init() {
+	ququery.Relations.
+		BelongsTo("members", "team", "teams", "team_code", "code").
+		BelongsTo("members", "country", "countries", "", "").
+		HasOne("members", "profile", "profiles", "member_id", "").
+		HasMany("members", "posts", "posts", "author_id", "").
+		ManyToMany("members", "tags", "tags", "member_tag", "member_id", "tag_id")
+}
+
+func TestSelectQuery_Relations(t *testing.T) {
+	testcases := testutil.Testcases{
+		"with registered relations": testutil.Testcase{
+			Query:       ququery.Select("members").With("team", "country", "profile").Query(),
+			ExpectedSQL: "SELECT * FROM members LEFT JOIN teams ON teams.code = members.team_code LEFT JOIN countries ON countries.id = members.country_id LEFT JOIN profiles ON profiles.member_id = members.id",
+			Doc:         "empty keys come from the naming strategy",
+		},
+		"with falls back to the naming strategy": testutil.Testcase{
+			Query:       ququery.Select("members").With("city").Query(),
+			ExpectedSQL: "SELECT * FROM members LEFT JOIN cities ON cities.id = members.city_id",
+			Doc:         "unregistered entities are belongs-to relations",
+		},
+		"join a has-many relation": testutil.Testcase{
+			Query:       ququery.Select("members").JoinRelation("posts").Query(),
+			ExpectedSQL: "SELECT * FROM members INNER JOIN posts ON posts.author_id = members.id",
+			Doc:         "has-many relations join on the related table's foreign key",
+		},
+		"join a many-to-many relation": testutil.Testcase{
+			Query:       ququery.Select("members").LeftJoinRelation("tags").Query(),
+			ExpectedSQL: "SELECT * FROM members LEFT JOIN member_tag ON member_tag.member_id = members.id LEFT JOIN tags ON tags.id = member_tag.tag_id",
+			Doc:         "many-to-many relations join the pivot table",
+		},
+		"join an unknown relation": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnknownRelation,
+			Doc:         "relations must be registered to be joined",
+		}.Build(ququery.Select("members").JoinRelation("city")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_WhereHas(t *testing.T) {
+	testcases := testutil.Testcases{
+		"where has with constraints": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM members WHERE active = $1 AND EXISTS (SELECT 1 FROM posts WHERE posts.author_id = members.id AND (status = $2 OR pinned = $3))",
+			ExpectedArgs: []any{true, "published", true},
+			Doc:          "constraints are grouped after the correlation",
+		}.Build(ququery.Select("members").Where("active").WhereHas("posts", func(q *ququery.SelectQuery) {
+			q.Where("status").OrWhere("pinned")
+		}), true, "published", true),
+		"or where has a belongs-to relation": testutil.Testcase{
+			Query:       ququery.Select("members").Where("admin").OrWhereHas("team", nil).Query(),
+			ExpectedSQL: "SELECT * FROM members WHERE admin = $1 OR EXISTS (SELECT 1 FROM teams WHERE teams.code = members.team_code)",
+			Doc:         "belongs-to relations match the owner key",
+		},
+		"where doesn't have a many-to-many relation": testutil.Testcase{
+			Query: ququery.Select("members").WhereDoesntHave("tags", func(q *ququery.SelectQuery) {
+				q.WhereIn("tags.name", "spam", "abuse")
+			}).Query(),
+			ExpectedSQL: "SELECT * FROM members WHERE NOT EXISTS (SELECT 1 FROM tags INNER JOIN member_tag ON tags.id = member_tag.tag_id WHERE member_tag.member_id = members.id AND (tags.name IN ($1, $2)))",
+			Doc:         "many-to-many relations join the pivot table",
+		},
+		"where has an unregistered relation": testutil.Testcase{
+			Query:       ququery.Select("users").OrWhereDoesntHave("role", nil).Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE NOT EXISTS (SELECT 1 FROM roles WHERE roles.id = users.role_id)",
+			Doc:         "unregistered relations follow the naming strategy like With",
+		},
+		"where has count": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM members WHERE (SELECT COUNT(*) FROM posts WHERE posts.author_id = members.id) >= ? AND name = ?",
+			ExpectedArgs: []any{3, "adel"},
+			Doc:          "the count is bound in placeholder order",
+		}.Build(ququery.Select("members").Dialect(ququery.MySQL).WhereHasCount("posts", ">=", 3).Where("name"), "adel"),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_WithJSON(t *testing.T) {
+	testcases := testutil.Testcases{
+		"has-many relation as a json array": testutil.Testcase{
+			ExpectedSQL:  "SELECT *, (SELECT COALESCE(json_agg(row_to_json(posts) ORDER BY posts.created_at DESC), '[]'::json) FROM (SELECT * FROM posts WHERE posts.author_id = members.id AND (published = $1) ORDER BY created_at DESC LIMIT $2 OFFSET $3) AS posts) AS posts FROM members WHERE active = $4",
+			ExpectedArgs: []any{true, 5, 0, true},
+			Doc:          "children are filtered, sorted and limited in a derived table",
+		}.Build(ququery.Select("members").WithJSON("posts", func(q *ququery.SelectQuery) {
+			q.Where("published").OrderBy("created_at", ququery.DESC).Paginate(1, 5)
+		}).Where("active"), true, true),
+		"belongs-to relation as a json object": testutil.Testcase{
+			Query:       ququery.Select("members").Columns("members.id").WithJSON("team", nil).Query(),
+			ExpectedSQL: "SELECT members.id, (SELECT row_to_json(team) FROM (SELECT * FROM teams WHERE teams.code = members.team_code) AS team) AS team FROM members",
+			Doc:         "single relations are objects",
+		},
+		"many-to-many relation on mysql": testutil.Testcase{
+			Query: ququery.Select("members").Dialect(ququery.MySQL).WithJSON("tags", func(q *ququery.SelectQuery) {
+				q.Columns("tags.id", "tags.name AS label")
+			}).Query(),
+			ExpectedSQL: "SELECT *, (SELECT COALESCE(JSON_ARRAYAGG(JSON_OBJECT('id', tags.id, 'label', tags.label)), JSON_ARRAY()) FROM (SELECT tags.id, tags.name AS label FROM tags INNER JOIN member_tag ON tags.id = member_tag.tag_id WHERE member_tag.member_id = members.id) AS tags) AS tags FROM members",
+			Doc:         "mysql json objects are built from the selected columns",
+		},
+		"sqlite": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "json relations aren't supported on sqlite",
+		}.Build(ququery.Select("members").Dialect(ququery.SQLite).WithJSON("posts", nil)),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	if _, _, err := ququery.Select("members").Dialect(ququery.MySQL).WithJSON("posts", nil).Build(); err == nil {
+		t.Error("expected an error for a mysql json relation without columns")
+	}
+}
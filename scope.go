@@ -0,0 +1,113 @@
+package ququery
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Scope is a reusable set of conditions applied to a query of type T.
+//
+// Scopes of *MultiWhere can be applied to every builder with Scopes, so a single
+// definition filters selects, updates, deletes and exists queries the same way.
+//
+// Example:
+//
+//	var Active ququery.Scope[*ququery.MultiWhere] = func(q *ququery.MultiWhere) *ququery.MultiWhere {
+//		return q.WhereNull("deleted_at").WhereValue("banned", "=", false)
+//	}
+//
+//	func OwnedBy(userID int) ququery.Scope[*ququery.MultiWhere] {
+//		return func(q *ququery.MultiWhere) *ququery.MultiWhere {
+//			return q.WhereValue("user_id", "=", userID)
+//		}
+//	}
+type Scope[T whereable] func(q T) T
+
+var (
+	scopesMu sync.RWMutex
+	scopes   = map[string]Scope[*MultiWhere]{}
+)
+
+// RegisterScope registers the scope by name, so it can be applied with NamedScopes.
+func RegisterScope(name string, scope Scope[*MultiWhere]) {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	scopes[name] = scope
+}
+
+// Scopes applies the scopes to the query. The conditions of a scope are added with "and",
+// and wrapped in parentheses when they contain an "or" clause. When the conditions added
+// before the scope contain an "or" clause, they are wrapped in parentheses too, so the
+// scope applies to every row the query matches.
+//
+// Example:
+//
+//	query, args, err := ququery.Update("posts").Set("title").Scopes(Active, OwnedBy(7)).Build("Hello")
+//	log.Println(query, args) => UPDATE posts SET title = $1 WHERE deleted_at IS NULL AND banned = $2 AND user_id = $3 [Hello false 7]
+func (c *WhereContainer[T]) Scopes(scopes ...Scope[*MultiWhere]) T {
+	for _, scope := range scopes {
+		c.scope(scope)
+	}
+
+	return c.self
+}
+
+// NamedScopes applies the scopes registered with RegisterScope by name. Building a query
+// with a name that isn't registered returns ErrUnknownScope.
+//
+// Example:
+//
+//	ququery.RegisterScope("active", Active)
+//	query := ququery.Delete("sessions").NamedScopes("active").Query()
+//	log.Println(query) => DELETE FROM sessions WHERE deleted_at IS NULL AND banned = $1
+func (c *WhereContainer[T]) NamedScopes(names ...string) T {
+	for _, name := range names {
+		scopesMu.RLock()
+		scope, ok := scopes[name]
+		scopesMu.RUnlock()
+
+		if !ok {
+			err := fmt.Errorf("%w: %s", ErrUnknownScope, name)
+
+			c.conditions = append(c.conditions, whereStructure{
+				isAnd: true,
+				build: func(env) (string, []any, error) {
+					return "", nil, err
+				},
+			})
+
+			continue
+		}
+
+		c.scope(scope)
+	}
+
+	return c.self
+}
+
+func (c *WhereContainer[T]) scope(scope Scope[*MultiWhere]) {
+	w := scope(newMultiWhere(c.dialect))
+	if len(w.conditions) == 0 {
+		return
+	}
+
+	if hasOr(w.conditions) {
+		c.conditions = guardConditions(c.conditions, whereStructure{isAnd: true, group: w.conditions})
+		return
+	}
+
+	w.conditions[0].isAnd = true
+	c.conditions = guardConditions(c.conditions, w.conditions...)
+}
+
+// hasOr reports whether conditions are joined by an "or" clause.
+func hasOr(conditions []whereStructure) bool {
+	for i, condition := range conditions {
+		if i > 0 && !condition.isAnd {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+var active ququery.Scope[*ququery.MultiWhere] = func(q *ququery.MultiWhere) *ququery.MultiWhere {
+	return q.WhereNull("deleted_at").WhereValue("banned", "=", false)
+}
+
+func ownedBy(userID int) ququery.Scope[*ququery.MultiWhere] {
+	return func(q *ququery.MultiWhere) *ququery.MultiWhere {
+		return q.WhereValue("user_id", "=", userID)
+	}
+}
+
+var visible ququery.Scope[*ququery.MultiWhere] = func(q *ququery.MultiWhere) *ququery.MultiWhere {
+	return q.WhereValue("public", "=", true).OrWhereNotNull("shared_at")
+}
+
+func init() {
+	ququery.RegisterScope("active", active)
+}
+
+func TestScopes(t *testing.T) {
+	testcases := testutil.Testcases{
+		"scopes on a select": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM posts WHERE id = $1 AND deleted_at IS NULL AND banned = $2 AND user_id = $3",
+			ExpectedArgs: []any{1, false, 7},
+			Doc:          "scope conditions are added with and",
+		}.Build(ququery.Select("posts").Where("id").Scopes(active, ownedBy(7)), 1),
+		"scopes on an update": testutil.Testcase{
+			ExpectedSQL:  "UPDATE posts SET title = $1 WHERE deleted_at IS NULL AND banned = $2 AND user_id = $3",
+			ExpectedArgs: []any{"Hello", false, 7},
+			Doc:          "the same scopes filter updates",
+		}.Build(ququery.Update("posts").Set("title").Scopes(active, ownedBy(7)), "Hello"),
+		"scopes on a delete": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM posts WHERE user_id = ? AND (public = ? OR shared_at IS NOT NULL)",
+			ExpectedArgs: []any{7, true},
+			Doc:          "scopes with or clauses are grouped",
+		}.Build(ququery.Delete("posts").Dialect(ququery.MySQL).Scopes(ownedBy(7), visible)),
+		"named scopes on an exists": testutil.Testcase{
+			ExpectedSQL:  "SELECT EXISTS(SELECT true FROM users WHERE (email = $1 OR id = $2) AND deleted_at IS NULL AND banned = $3)",
+			ExpectedArgs: []any{"a@b.c", 1, false},
+			Doc:          "or clauses before a scope are grouped",
+		}.Build(ququery.Exists("users").Where("email").OrWhere("id").NamedScopes("active"), "a@b.c", 1),
+		"unknown named scope": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnknownScope,
+			Doc:         "scopes must be registered",
+		}.Build(ququery.Select("users").NamedScopes("archived")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
@@ -9,22 +9,49 @@ import (
 
 type (
 	SelectQuery struct {
-		table   string
-		columns []string
+		table      string
+		from       *SelectQuery
+		columns    []string
+		subColumns []subColumn
 		WhereContainer[*SelectQuery]
-		joins            []join
-		orderBy          []string
-		hasLimit         bool
-		hasOffset        bool
-		withoutRebinding bool
+		joins     []join
+		orderBy   []order
+		rank      *fullText
+		hasLimit  bool
+		hasOffset bool
+		limit     any
+		offset    any
+		naming    NamingStrategy
+
+		// outer is the env of the query a WhereInSubquery callback is handed by.
+		// The query is rendered in it and left for the outer query to rebind.
+		outer *env
 	}
 
 	joinType string
 
+	order struct {
+		column    string
+		direction string
+	}
+
 	join struct {
 		table       string
+		sub         *SelectQuery
 		constraints string
+		on          []whereStructure
+		using       []string
+		lateral     bool
 		jType       joinType
+		err         error
+	}
+
+	subColumn struct {
+		query *SelectQuery
+		alias string
+
+		// aggregate, when set, renders the column aggregating the rows of the query.
+		aggregate func(dialect Dialect) (string, error)
 	}
 )
 
@@ -47,10 +74,25 @@ func Select(table string) *SelectQuery {
 	return s
 }
 
+// SelectFrom selects from the results of a subquery, known by alias in the outer query.
+// The subquery is rendered with the outer query's dialect and its arguments are merged in.
+//
+// Example:
+//
+//	totals := ququery.Select("orders").Columns("user_id", "SUM(total) AS total").Where("status")
+//	query := ququery.SelectFrom(totals, "totals").Where("total", ">").Query()
+//	log.Println(query) => SELECT * FROM (SELECT user_id, SUM(total) AS total FROM orders WHERE status = $1) AS totals WHERE total > $2
+func SelectFrom(sub *SelectQuery, alias string) *SelectQuery {
+	s := Select(alias)
+	s.from = sub
+
+	return s
+}
+
 func (q *SelectQuery) Table(table string) *SelectQuery {
 	q.table = table
 
-	q.WhereContainer = WhereContainer[*SelectQuery]{self: q}
+	q.WhereContainer = WhereContainer[*SelectQuery]{self: q, dialect: q.dialect, trashed: q.trashed, ctx: q.ctx}
 
 	return q
 }
@@ -61,6 +103,20 @@ func (q *SelectQuery) Columns(columns ...string) *SelectQuery {
 	return q
 }
 
+// ColumnSub adds a subquery column, known by alias in the results. Subquery columns
+// come after the columns passed to Columns.
+//
+// Example:
+//
+//	posts := ququery.Select("posts").Columns("COUNT(*)").Where("published")
+//	query := ququery.Select("users").Columns("users.id").ColumnSub(posts, "post_count").Query()
+//	log.Println(query) => SELECT users.id, (SELECT COUNT(*) FROM posts WHERE published = $1) AS post_count FROM users
+func (q *SelectQuery) ColumnSub(sub *SelectQuery, alias string) *SelectQuery {
+	q.subColumns = append(q.subColumns, subColumn{query: sub, alias: alias})
+
+	return q
+}
+
 // Join method used to add inner join to your queries
 //
 // Example:
@@ -109,23 +165,115 @@ func (q *SelectQuery) RightJoin(table, constraints string) *SelectQuery {
 	return q
 }
 
-// With can load one-to-many relations without need to pass join column
+// JoinSub method used to add inner join on the results of a subquery, known by alias in the query.
+//
+// Example:
+//
+//	latest := ququery.Select("posts").Columns("user_id", "MAX(created_at) AS published_at").Where("published")
+//	query := ququery.Select("users").JoinSub(latest, "latest", "latest.user_id = users.id").Query()
+//	log.Println(query) => SELECT * FROM users INNER JOIN (SELECT user_id, MAX(created_at) AS published_at FROM posts WHERE published = $1) AS latest ON latest.user_id = users.id
+func (q *SelectQuery) JoinSub(sub *SelectQuery, alias, constraints string) *SelectQuery {
+	q.joins = append(q.joins, join{
+		table:       alias,
+		sub:         sub,
+		constraints: constraints,
+		jType:       innerJoin,
+	})
+
+	return q
+}
+
+// LeftJoinSub method used to add left join on the results of a subquery.
+// It has the same signature as JoinSub.
+func (q *SelectQuery) LeftJoinSub(sub *SelectQuery, alias, constraints string) *SelectQuery {
+	q.joins = append(q.joins, join{
+		table:       alias,
+		sub:         sub,
+		constraints: constraints,
+		jType:       leftJoin,
+	})
+
+	return q
+}
+
+// With can load one-to-many relations without need to pass join column.
+// Relations registered in Relations are joined as registered, other entities
+// get their table and key names from the query's naming strategy.
+//
+// Example:
+//
+//	query := ququery.Select("users").With("role", "address").Query()
+//	log.Println(query) => SELECT * FROM users LEFT JOIN roles ON roles.id = users.role_id LEFT JOIN addresses ON addresses.id = users.address_id
 func (q *SelectQuery) With(entities ...string) *SelectQuery {
 	for _, entity := range entities {
-		table := findTableFromEntity(entity)
+		q.joinResolved(q.Relation(entity), leftJoin)
+	}
+
+	return q
+}
 
-		q.joins = append(q.joins, join{
-			table:       table,
-			constraints: fmt.Sprintf("%s.id = %s.%s", table, q.table, entity+"_id"),
-			jType:       leftJoin,
-		})
+// WithRelation loads a relation like With, with the table, the foreign key of the query's table
+// and the primary key of the relation's table given. Empty names come from the naming strategy.
+//
+// Example:
+//
+//	query := ququery.Select("posts").WithRelation("author", "users", "written_by", "").Query()
+//	log.Println(query) => SELECT * FROM posts LEFT JOIN users ON users.id = posts.written_by
+func (q *SelectQuery) WithRelation(entity, table, foreignKey, primaryKey string) *SelectQuery {
+	if table == "" {
+		table = q.namingStrategy().Table(entity)
+	}
+
+	relation := Relation{
+		Kind:       RelationBelongsTo,
+		Table:      q.table,
+		Name:       entity,
+		Related:    table,
+		ForeignKey: foreignKey,
+		OwnerKey:   primaryKey,
 	}
 
+	q.joinResolved(relation.resolve(q.namingStrategy()), leftJoin)
+
+	return q
+}
+
+// Naming sets the naming strategy With uses for this query instead of the package's default.
+func (q *SelectQuery) Naming(strategy NamingStrategy) *SelectQuery {
+	q.naming = strategy
+
 	return q
 }
 
+func (q *SelectQuery) namingStrategy() NamingStrategy {
+	if q.naming != nil {
+		return q.naming
+	}
+
+	return defaultNaming()
+}
+
+// OrderBy sorts the results by the column. Calling it again replaces the sort,
+// use ThenBy to sort by more columns.
+//
+// Example:
+//
+//	query := ququery.Select("users").OrderBy("created_at", ququery.DESC).Query()
+//	log.Println(query) => SELECT * FROM users ORDER BY created_at DESC
 func (q *SelectQuery) OrderBy(column, direction string) *SelectQuery {
-	q.orderBy = []string{column, direction}
+	q.orderBy = []order{{column: column, direction: strings.ToUpper(direction)}}
+
+	return q
+}
+
+// ThenBy sorts the results by the column after the columns of OrderBy and previous ThenBy calls.
+//
+// Example:
+//
+//	query := ququery.Select("users").OrderBy("created_at", ququery.DESC).ThenBy("name", ququery.ASC).Query()
+//	log.Println(query) => SELECT * FROM users ORDER BY created_at DESC, name ASC
+func (q *SelectQuery) ThenBy(column, direction string) *SelectQuery {
+	q.orderBy = append(q.orderBy, order{column: column, direction: strings.ToUpper(direction)})
 
 	return q
 }
@@ -142,58 +290,218 @@ func (q *SelectQuery) Offset() *SelectQuery {
 	return q
 }
 
-func (q *SelectQuery) prepareSelectQuery() string {
-	columns := strings.Join(q.columns, ", ")
-	if len(q.columns) == 0 {
-		columns = "*"
+// Paginate limits the results to a page of perPage rows. Pages start from 1 and
+// both the limit and the offset are bound to the query.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").Paginate(3, 20).Build()
+//	log.Println(query, args) => SELECT * FROM users LIMIT $1 OFFSET $2 [20 40]
+func (q *SelectQuery) Paginate(page, perPage int) *SelectQuery {
+	if page < 1 {
+		page = 1
 	}
 
-	query := fmt.Sprintf("SELECT %s FROM %s", columns, q.table)
+	q.hasLimit, q.limit = true, perPage
+	q.hasOffset, q.offset = true, (page-1)*perPage
+
+	return q
+}
+
+func (q *SelectQuery) prepareSelectQuery(e env) (string, []any, error) {
+	dialect := e.dialect
+
+	columns := q.columns
+	if len(columns) == 0 && len(q.subColumns) == 0 {
+		columns = []string{"*"}
+	}
+
+	args := placeholders(strings.Join(columns, ", "))
+
+	for _, column := range q.subColumns {
+		sub, subArgs, err := column.query.prepareSelectQuery(e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if column.aggregate != nil {
+			aggregate, err := column.aggregate(dialect)
+			if err != nil {
+				return "", nil, err
+			}
+
+			sub = fmt.Sprintf("SELECT %s FROM (%s) AS %s", aggregate, sub, column.alias)
+		}
+
+		columns = append(columns, fmt.Sprintf("(%s) AS %s", sub, column.alias))
+		args = append(args, subArgs...)
+	}
+
+	from := q.table
+	if q.from != nil {
+		sub, subArgs, err := q.from.prepareSelectQuery(e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		from = fmt.Sprintf("(%s) AS %s", sub, q.table)
+		args = append(args, subArgs...)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), from)
 
 	if len(q.joins) > 0 {
-		query += " " + q.prepareJoinQuery(q.joins)
+		joins, joinArgs, err := q.prepareJoinQuery(q.joins, e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += " " + joins
+		args = append(args, joinArgs...)
 	}
 
-	if len(q.conditions) > 0 {
-		query += " " + prepareWhereQuery(q.conditions)
+	conditions := q.conditions
+	if q.from == nil {
+		conditions = tenantConditions(q.softDeleteConditions(q.table, q.trashed), q.table)
 	}
 
-	if len(q.orderBy) > 0 {
-		query += fmt.Sprintf(" ORDER BY %s %s", q.orderBy[0], strings.ToUpper(q.orderBy[1]))
+	for _, join := range q.joins {
+		if join.sub == nil && join.guardedInWhere() {
+			conditions = tenantConditions(conditions, join.table)
+		}
+	}
+
+	if len(conditions) > 0 {
+		where, whereArgs, err := prepareWhereQuery(conditions, e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += " " + where
+		args = append(args, whereArgs...)
+	}
+
+	if q.rank != nil {
+		rank, err := q.rank.rank(dialect)
+		if err != nil {
+			return "", nil, err
+		}
+
+		query += fmt.Sprintf(" ORDER BY %s DESC", rank)
+		args = append(args, q.rank.term)
+
+		if len(q.orderBy) > 0 {
+			query += ", " + prepareOrderByQuery(q.orderBy)
+		}
+	} else if len(q.orderBy) > 0 {
+		query += " ORDER BY " + prepareOrderByQuery(q.orderBy)
 	}
 
 	if q.hasLimit {
 		query += " LIMIT ?"
+		args = append(args, bindOrPlaceholder(q.limit))
 	}
 
 	if q.hasOffset {
 		query += " OFFSET ?"
+		args = append(args, bindOrPlaceholder(q.offset))
 	}
 
-	return strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(query, "\n", ""), "\t", ""))
+	return strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(query, "\n", ""), "\t", "")), args, nil
 }
 
+// Query returns the SQL of the query, or an empty string when the query can't be built.
+//
+// Deprecated: Query hides build errors, and some drivers run an empty query as a no-op.
+// Use Build, which returns the build error and the bound arguments.
 func (q *SelectQuery) Query() string {
-	query := q.prepareSelectQuery()
+	query, _, err := q.build()
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// Build returns the SQL of the query with its arguments in placeholder order.
+// Values are used for the placeholders whose value is not bound by the builder, in order.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("posts").Where("user_id").WhereJSONContains("tags", []string{"go"}).Build(userID)
+//	log.Println(query, args) => SELECT * FROM posts WHERE user_id = $1 AND tags @> $2 [userID ["go"]]
+func (q *SelectQuery) Build(values ...any) (string, []any, error) {
+	query, args, err := q.build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args, err = bindArgs(args, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
+}
+
+func (q *SelectQuery) build() (string, []any, error) {
+	e := q.env()
+	if q.outer != nil {
+		e = *q.outer
+	}
+
+	query, args, err := q.prepareSelectQuery(e)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if q.outer != nil {
+		// Only the SQL of the query is returned to the outer query, so its values can't be bound.
+		for _, arg := range args {
+			if _, ok := arg.(placeholder); !ok {
+				return "", nil, fmt.Errorf("ququery: subquery callbacks can't bind values, use WhereInSub")
+			}
+		}
 
-	if q.withoutRebinding {
-		return query
+		return query, args, nil
 	}
 
-	return sqlx.Rebind(sqlx.DOLLAR, query)
+	return sqlx.Rebind(q.dialect.bindType(), query), args, nil
 }
 
-func (q *SelectQuery) prepareJoinQuery(joins []join) string {
-	var joinQuery string
+func prepareOrderByQuery(orders []order) string {
+	columns := make([]string, len(orders))
+	for i, o := range orders {
+		columns[i] = o.column + " " + o.direction
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// bindOrPlaceholder returns value, or a placeholder when the value is left to the caller.
+func bindOrPlaceholder(value any) any {
+	if value == nil {
+		return placeholder{}
+	}
+
+	return value
+}
+
+func (q *SelectQuery) prepareJoinQuery(joins []join, e env) (string, []any, error) {
+	var (
+		queries []string
+		args    []any
+	)
 
 	for _, join := range joins {
-		joinQuery += fmt.Sprintf(
-			" %s JOIN %s ON %s",
-			join.jType,
-			join.table,
-			join.constraints,
-		)
+		query, joinArgs, err := join.prepare(e)
+		if err != nil {
+			return "", nil, err
+		}
+
+		queries = append(queries, query)
+		args = append(args, joinArgs...)
 	}
 
-	return joinQuery
+	return strings.Join(queries, " "), args, nil
 }
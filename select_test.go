@@ -103,11 +103,10 @@ func TestSelectQuery_Strpos(t *testing.T) {
 func TestSelectQuery_WhereGroup(t *testing.T) {
 	testcases := testutil.Testcases{
 		"select query with multi where": testutil.Testcase{
-			Query: ququery.Select("users").WhereGroup(func(subQuery ququery.MultiWhere) string {
-				return subQuery.Where("email").
+			Query: ququery.Select("users").WhereGroup(func(subQuery *ququery.MultiWhere) {
+				subQuery.Where("email").
 					Where("role_id").
-					OrWhere("type").
-					Query()
+					OrWhere("type")
 			}).Query(),
 			ExpectedSQL: "SELECT * FROM users WHERE ( email = $1 AND role_id = $2 OR type = $3)",
 			Doc:         "select query with group of where conditions",
@@ -197,3 +196,99 @@ func TestSelectQuery_WhereNull(t *testing.T) {
 
 	testutil.RunTests(t, testcases, nil)
 }
+
+func TestSelectQuery_Build(t *testing.T) {
+	testcases := testutil.Testcases{
+		"build with caller values": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE id = $1 LIMIT $2",
+			ExpectedArgs: []any{1, 10},
+			Doc:          "values passed to build fill the placeholders in order",
+		}.Build(ququery.Select("users").Where("id").Limit(), 1, 10),
+		"build with missing values": testutil.Testcase{
+			ExpectedErr: ququery.ErrArgumentCount,
+			Doc:         "build fails when a placeholder has no value",
+		}.Build(ququery.Select("users").Where("id").Limit(), 1),
+		"build with extra values": testutil.Testcase{
+			ExpectedErr: ququery.ErrArgumentCount,
+			Doc:         "build fails when there are more values than placeholders",
+		}.Build(ququery.Select("users").Where("id"), 1, 2),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_When(t *testing.T) {
+	name, roles := "adel", []any{}
+
+	testcases := testutil.Testcases{
+		"select with optional filters": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE active = $1 AND name LIKE $2 ESCAPE '\\' LIMIT $3",
+			ExpectedArgs: []any{true, "%adel%", 20},
+			Doc:          "optional filters keep the chain and the argument order",
+		}.Build(ququery.Select("users").
+			Where("active").
+			When(name != "", func(q *ququery.SelectQuery) *ququery.SelectQuery {
+				return q.WhereContains("name", name)
+			}).
+			Unless(len(roles) == 0, func(q *ququery.SelectQuery) *ququery.SelectQuery {
+				return q.WhereIn("role_id", roles...)
+			}).
+			Limit(), true, 20),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_OrderByAndPaginate(t *testing.T) {
+	testcases := testutil.Testcases{
+		"order by several columns": {
+			Query:       ququery.Select("users").OrderBy("created_at", "desc").ThenBy("name", ququery.ASC).Query(),
+			ExpectedSQL: "SELECT * FROM users ORDER BY created_at DESC, name ASC",
+			Doc:         "sort users by creation date and name",
+		},
+		"order by replaces the sort": {
+			Query:       ququery.Select("users").OrderBy("id", ququery.ASC).ThenBy("name", ququery.ASC).OrderBy("created_at", "desc").Query(),
+			ExpectedSQL: "SELECT * FROM users ORDER BY created_at DESC",
+			Doc:         "override a default sort",
+		},
+		"paginate": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE role_id = $1 LIMIT $2 OFFSET $3",
+			ExpectedArgs: []any{2, 20, 40},
+			Doc:          "paginate binds the limit and offset",
+		}.Build(ququery.Select("users").Where("role_id").Paginate(3, 20), 2),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestSelectQuery_Subqueries(t *testing.T) {
+	totals := ququery.Select("orders").Columns("user_id", "SUM(total) AS total").Where("status").WhereIn("region", "eu", "us")
+	posts := ququery.Select("posts").Columns("COUNT(*)").Where("published")
+	latest := ququery.Select("posts").Columns("user_id", "MAX(created_at) AS published_at").Where("published")
+	orders := ququery.Select("orders").Columns("user_id").Where("total", ">")
+
+	testcases := testutil.Testcases{
+		"select from a subquery": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM (SELECT user_id, SUM(total) AS total FROM orders WHERE status = $1 AND region IN ($2, $3)) AS totals WHERE total > $4",
+			ExpectedArgs: []any{"paid", "eu", "us", 100},
+			Doc:          "the derived table's placeholders come first",
+		}.Build(ququery.SelectFrom(totals, "totals").Where("total", ">"), "paid", 100),
+		"subquery columns": testutil.Testcase{
+			ExpectedSQL:  "SELECT users.id, (SELECT COUNT(*) FROM posts WHERE published = $1) AS post_count FROM users WHERE active = $2",
+			ExpectedArgs: []any{true, false},
+			Doc:          "subquery columns come after plain columns",
+		}.Build(ququery.Select("users").Columns("users.id").ColumnSub(posts, "post_count").Where("active"), true, false),
+		"join a subquery": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users LEFT JOIN (SELECT user_id, MAX(created_at) AS published_at FROM posts WHERE published = ?) AS latest ON latest.user_id = users.id WHERE users.id IN (SELECT user_id FROM orders WHERE total > ?)",
+			ExpectedArgs: []any{true, 100},
+			Doc:          "subqueries render with the outer query's dialect",
+		}.Build(ququery.Select("users").Dialect(ququery.MySQL).LeftJoinSub(latest, "latest", "latest.user_id = users.id").WhereInSub("users.id", orders), true, 100),
+		"or where in a subquery": testutil.Testcase{
+			Query:       ququery.Select("users").Where("admin").OrWhereInSub("users.id", orders).Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE admin = $1 OR users.id IN (SELECT user_id FROM orders WHERE total > $2)",
+			Doc:         "or where in subquery",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
@@ -0,0 +1,137 @@
+package ququery
+
+import (
+	"fmt"
+	"sync"
+)
+
+type trashedMode int
+
+const (
+	withoutTrashed trashedMode = iota
+	withTrashed
+	onlyTrashed
+)
+
+var (
+	softDeletesMu sync.RWMutex
+
+	// softDeletes maps the soft deleted tables to their deletion column.
+	softDeletes = map[string]string{}
+)
+
+// SoftDeletes makes the rows of table soft deleted with column, like "deleted_at". Select,
+// exists and update queries on the table skip the deleted rows, whose column isn't NULL,
+// and delete queries set the column to the current time instead of deleting the rows.
+//
+// Example:
+//
+//	ququery.SoftDeletes("posts", "deleted_at")
+//
+//	query := ququery.Select("posts").Where("user_id").Query()
+//	log.Println(query) => SELECT * FROM posts WHERE user_id = $1 AND posts.deleted_at IS NULL
+//
+//	query = ququery.Delete("posts").Where("id").Query()
+//	log.Println(query) => UPDATE posts SET deleted_at = NOW() WHERE id = $1 AND posts.deleted_at IS NULL
+func SoftDeletes(table, column string) {
+	softDeletesMu.Lock()
+	defer softDeletesMu.Unlock()
+
+	softDeletes[table] = column
+}
+
+// softDeleteColumn returns the deletion column of table, which can have an alias.
+func softDeleteColumn(table string) (string, bool) {
+	name, _ := tableReference(table)
+
+	softDeletesMu.RLock()
+	defer softDeletesMu.RUnlock()
+
+	column, ok := softDeletes[name]
+
+	return column, ok
+}
+
+// WithTrashed includes the soft deleted rows in the query. It has no effect on soft
+// deletes, which never mark the deleted rows again, so they keep their deletion time.
+func (c *WhereContainer[T]) WithTrashed() T {
+	c.trashed = withTrashed
+
+	return c.self
+}
+
+// OnlyTrashed limits the query to the soft deleted rows. Like WithTrashed, it has no effect on soft deletes.
+//
+// Example:
+//
+//	query := ququery.Select("posts").OnlyTrashed().Query()
+//	log.Println(query) => SELECT * FROM posts WHERE posts.deleted_at IS NOT NULL
+func (c *WhereContainer[T]) OnlyTrashed() T {
+	c.trashed = onlyTrashed
+
+	return c.self
+}
+
+// softDeleteConditions returns the conditions of the query on table with the condition skipping,
+// or keeping only, the soft deleted rows as trashed says when the table is soft deleted.
+func (c *WhereContainer[T]) softDeleteConditions(table string, trashed trashedMode) []whereStructure {
+	column, ok := softDeleteColumn(table)
+	if !ok || trashed == withTrashed {
+		return c.conditions
+	}
+
+	_, reference := tableReference(table)
+
+	condition := fmt.Sprintf("%s.%s IS NULL", reference, column)
+	if trashed == onlyTrashed {
+		condition = fmt.Sprintf("%s.%s IS NOT NULL", reference, column)
+	}
+
+	return guardConditions(c.conditions, whereStructure{isAnd: true, isRaw: true, rawQuery: condition})
+}
+
+// guardConditions adds the guards to the conditions with "and". The conditions are
+// grouped when they contain an "or" clause, so the guards apply to all of them.
+func guardConditions(conditions []whereStructure, guards ...whereStructure) []whereStructure {
+	if hasOr(conditions) {
+		conditions = []whereStructure{{isAnd: true, group: conditions}}
+	}
+
+	return append(conditions[:len(conditions):len(conditions)], guards...)
+}
+
+// now returns the current time in the dialect.
+func now(dialect Dialect) string {
+	if dialect == SQLite {
+		return "CURRENT_TIMESTAMP"
+	}
+
+	return "NOW()"
+}
+
+// ForceDelete deletes the rows of a soft deleted table instead of marking them as deleted.
+// It deletes the soft deleted rows too, or only them with OnlyTrashed.
+//
+// Example:
+//
+//	query := ququery.Delete("posts").Where("id").ForceDelete().Query()
+//	log.Println(query) => DELETE FROM posts WHERE id = $1
+func (q *DeleteQuery) ForceDelete() *DeleteQuery {
+	q.force = true
+
+	return q
+}
+
+// Restore sets the deletion column of the soft deleted rows matched by the query back to NULL.
+// Building a restore of a table that isn't soft deleted returns an error.
+//
+// Example:
+//
+//	query := ququery.Update("posts").Where("id").Restore().Query()
+//	log.Println(query) => UPDATE posts SET deleted_at = NULL WHERE id = $1 AND posts.deleted_at IS NOT NULL
+func (q *UpdateQuery) Restore() *UpdateQuery {
+	q.restore = true
+	q.trashed = onlyTrashed
+
+	return q
+}
@@ -0,0 +1,103 @@
+package ququery_test
+
+import (
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func init() {
+	ququery.SoftDeletes("drafts", "deleted_at")
+}
+
+func TestSoftDeletes(t *testing.T) {
+	testcases := testutil.Testcases{
+		"select skips deleted rows": testutil.Testcase{
+			Query:       ququery.Select("drafts").Where("id").OrWhere("slug").Query(),
+			ExpectedSQL: "SELECT * FROM drafts WHERE (id = $1 OR slug = $2) AND drafts.deleted_at IS NULL",
+			Doc:         "or clauses are grouped before the deleted rows are skipped",
+		},
+		"select with trashed": testutil.Testcase{
+			Query:       ququery.Select("drafts").WithTrashed().Query(),
+			ExpectedSQL: "SELECT * FROM drafts",
+			Doc:         "include the deleted rows",
+		},
+		"select only trashed": testutil.Testcase{
+			Query:       ququery.Select("drafts").OnlyTrashed().Where("user_id").Query(),
+			ExpectedSQL: "SELECT * FROM drafts WHERE user_id = $1 AND drafts.deleted_at IS NOT NULL",
+			Doc:         "only the deleted rows",
+		},
+		"exists skips deleted rows": testutil.Testcase{
+			Query:       ququery.Exists("drafts").Where("slug").Query(),
+			ExpectedSQL: "SELECT EXISTS(SELECT true FROM drafts WHERE slug = $1 AND drafts.deleted_at IS NULL)",
+			Doc:         "deleted rows don't exist",
+		},
+		"update skips deleted rows": testutil.Testcase{
+			Query:       ququery.Update("drafts").Set("title").Where("id").Query(),
+			ExpectedSQL: "UPDATE drafts SET title = $1 WHERE id = $2 AND drafts.deleted_at IS NULL",
+			Doc:         "deleted rows aren't updated",
+		},
+		"soft delete": testutil.Testcase{
+			Query:       ququery.Delete("drafts").Where("id").Query(),
+			ExpectedSQL: "UPDATE drafts SET deleted_at = NOW() WHERE id = $1 AND drafts.deleted_at IS NULL",
+			Doc:         "delete marks the rows as deleted",
+		},
+		"soft delete on sqlite": testutil.Testcase{
+			Query:       ququery.Delete("drafts").Dialect(ququery.SQLite).Where("id").Query(),
+			ExpectedSQL: "UPDATE drafts SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND drafts.deleted_at IS NULL",
+			Doc:         "sqlite has no NOW()",
+		},
+		"force delete": testutil.Testcase{
+			Query:       ququery.Delete("drafts").Where("id").ForceDelete().Query(),
+			ExpectedSQL: "DELETE FROM drafts WHERE id = $1",
+			Doc:         "force delete removes the rows",
+		},
+		"force delete only trashed": testutil.Testcase{
+			Query:       ququery.Delete("drafts").Where("user_id").OnlyTrashed().ForceDelete().Query(),
+			ExpectedSQL: "DELETE FROM drafts WHERE user_id = $1 AND drafts.deleted_at IS NOT NULL",
+			Doc:         "purge the deleted rows",
+		},
+		"restore": testutil.Testcase{
+			Query:       ququery.Update("drafts").Where("id").Restore().Query(),
+			ExpectedSQL: "UPDATE drafts SET deleted_at = NULL WHERE id = $1 AND drafts.deleted_at IS NOT NULL",
+			Doc:         "restore the deleted rows",
+		},
+		"relation filters skip deleted rows": testutil.Testcase{
+			Query:       ququery.Select("users").WhereHas("draft", nil).Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE EXISTS (SELECT 1 FROM drafts WHERE drafts.id = users.draft_id AND drafts.deleted_at IS NULL)",
+			Doc:         "subqueries on soft deleted tables skip the deleted rows",
+		},
+		"select with alias": testutil.Testcase{
+			Query:       ququery.Select("drafts AS d").Where("d.id").Query(),
+			ExpectedSQL: "SELECT * FROM drafts AS d WHERE d.id = $1 AND d.deleted_at IS NULL",
+			Doc:         "the deleted rows are skipped through the alias",
+		},
+		"soft delete with alias": testutil.Testcase{
+			Query:       ququery.Delete("drafts d").Where("d.id").Query(),
+			ExpectedSQL: "UPDATE drafts d SET deleted_at = NOW() WHERE d.id = $1 AND d.deleted_at IS NULL",
+			Doc:         "an alias without AS",
+		},
+		"soft delete with trashed": testutil.Testcase{
+			Query:       ququery.Delete("drafts").WithTrashed().Where("id").Query(),
+			ExpectedSQL: "UPDATE drafts SET deleted_at = NOW() WHERE id = $1 AND drafts.deleted_at IS NULL",
+			Doc:         "deleted rows keep their deletion time",
+		},
+		"table keeps only trashed": testutil.Testcase{
+			Query:       ququery.Select("users").OnlyTrashed().Table("drafts").Query(),
+			ExpectedSQL: "SELECT * FROM drafts WHERE drafts.deleted_at IS NOT NULL",
+			Doc:         "changing the table keeps the trashed mode",
+		},
+		"tables that aren't soft deleted": testutil.Testcase{
+			Query:       ququery.Delete("users").Where("id").Query(),
+			ExpectedSQL: "DELETE FROM users WHERE id = $1",
+			Doc:         "other tables are deleted",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	if _, _, err := ququery.Update("users").Restore().Build(); err == nil {
+		t.Error("expected an error when restoring a table that isn't soft deleted")
+	}
+}
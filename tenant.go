@@ -0,0 +1,146 @@
+package ququery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	tenantTablesMu sync.RWMutex
+
+	// tenantTables maps the tables shared by the tenants to their tenant column.
+	tenantTables = map[string]string{}
+)
+
+type tenantKey struct{}
+
+// TenantTables makes the rows of the tables belong to the tenant in column, like "tenant_id".
+// Select, update, delete and exists queries on the tables, joins and subqueries included,
+// only match the rows of the tenant of the query's context, and insert queries set the column.
+// Building a query on the tables without a tenant in its context returns ErrMissingTenant.
+//
+// Example:
+//
+//	ququery.TenantTables("tenant_id", "projects", "tasks")
+//
+//	ctx = ququery.WithTenant(ctx, 7)
+//	query, args, err := ququery.Select("projects").Context(ctx).Where("archived").Build(false)
+//	log.Println(query, args) => SELECT * FROM projects WHERE archived = $1 AND projects.tenant_id = $2 [false 7]
+func TenantTables(column string, tables ...string) {
+	tenantTablesMu.Lock()
+	defer tenantTablesMu.Unlock()
+
+	for _, table := range tables {
+		tenantTables[table] = column
+	}
+}
+
+func tenantColumn(table string) (string, bool) {
+	tenantTablesMu.RLock()
+	defer tenantTablesMu.RUnlock()
+
+	column, ok := tenantTables[table]
+
+	return column, ok
+}
+
+// WithTenant returns a copy of ctx carrying the tenant the queries built with it are limited to.
+func WithTenant(ctx context.Context, tenant any) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant set on ctx with WithTenant.
+func TenantFrom(ctx context.Context) (any, bool) {
+	tenant := ctx.Value(tenantKey{})
+
+	return tenant, tenant != nil
+}
+
+// Context sets the context of the query. The tenant of the context, set with WithTenant,
+// limits the query to its rows on the tables registered with TenantTables.
+func (c *WhereContainer[T]) Context(ctx context.Context) T {
+	c.ctx = ctx
+
+	return c.self
+}
+
+// env returns the environment the query is rendered in.
+func (c *WhereContainer[T]) env() env {
+	e := env{dialect: c.dialect}
+	if c.ctx != nil {
+		e.tenant, e.hasTenant = TenantFrom(c.ctx)
+	}
+
+	return e
+}
+
+// insertTenant returns the tenant column of table and the tenant of ctx an insert sets it to,
+// or an empty column when the table isn't registered with TenantTables. The columns of the
+// insert can't include the tenant column, which is only set from ctx.
+func insertTenant(ctx context.Context, table string, columns []string) (string, any, error) {
+	column, ok := tenantColumn(table)
+	if !ok {
+		return "", nil, nil
+	}
+
+	if slices.Contains(columns, column) {
+		return "", nil, fmt.Errorf("ququery: tenant column %s of %s is set from the context", column, table)
+	}
+
+	var tenant any
+	if ctx != nil {
+		tenant, _ = TenantFrom(ctx)
+	}
+
+	if tenant == nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrMissingTenant, table)
+	}
+
+	return column, tenant, nil
+}
+
+// tableReference returns the name of table and the name it's referred by,
+// which is its alias when it has one, like "users AS u".
+func tableReference(table string) (string, string) {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return table, table
+	}
+
+	return fields[0], fields[len(fields)-1]
+}
+
+// tenantGuard returns the condition limiting table to the tenant of the query,
+// when the table is registered with TenantTables.
+func tenantGuard(table string) (whereStructure, bool) {
+	name, reference := tableReference(table)
+
+	column, ok := tenantColumn(name)
+	if !ok {
+		return whereStructure{}, false
+	}
+
+	return whereStructure{
+		isAnd: true,
+		build: func(e env) (string, []any, error) {
+			if !e.hasTenant {
+				return "", nil, fmt.Errorf("%w: %s", ErrMissingTenant, name)
+			}
+
+			return fmt.Sprintf("%s.%s = ?", reference, column), []any{e.tenant}, nil
+		},
+	}, true
+}
+
+// tenantConditions adds the tenant guard of table to the conditions.
+func tenantConditions(conditions []whereStructure, table string) []whereStructure {
+	guard, ok := tenantGuard(table)
+	if !ok {
+		return conditions
+	}
+
+	return guardConditions(conditions, guard)
+}
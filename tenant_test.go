@@ -0,0 +1,182 @@
+package ququery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+)
+
+func init() {
+	ququery.TenantTables("tenant_id", "projects", "tasks", "project_user")
+}
+
+func TestTenantTables(t *testing.T) {
+	ctx := ququery.WithTenant(context.Background(), 7)
+	members := ququery.Pivot("project_user", "user_id", "project_id").Context(ctx)
+	detach, attach := members.Sync(3, 1, 2)
+
+	testcases := testutil.Testcases{
+		"select": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM projects WHERE (archived = $1 OR owner_id = $2) AND projects.tenant_id = $3",
+			ExpectedArgs: []any{false, 3, 7},
+			Doc:          "or clauses are grouped before the tenant guard",
+		}.Build(ququery.Select("projects").Context(ctx).Where("archived").OrWhere("owner_id"), false, 3),
+		"select with alias": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM projects AS p WHERE p.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "the guard uses the alias of the table",
+		}.Build(ququery.Select("projects AS p").Context(ctx)),
+		"joined table": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM projects INNER JOIN tasks ON (tasks.project_id = projects.id) AND tasks.tenant_id = $1 WHERE projects.tenant_id = $2",
+			ExpectedArgs: []any{7, 7},
+			Doc:          "joined tenant tables are guarded in the join",
+		}.Build(ququery.Select("projects").Context(ctx).Join("tasks", "tasks.project_id = projects.id")),
+		"joined table with or": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users LEFT JOIN tasks ON (tasks.owner_id = users.id OR tasks.reviewer_id = users.id) AND tasks.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "raw constraints with or are grouped",
+		}.Build(ququery.Select("users").Context(ctx).LeftJoin("tasks", "tasks.owner_id = users.id OR tasks.reviewer_id = users.id")),
+		"joined table with or before a bracket": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users INNER JOIN projects ON ((projects.owner_id = users.id) OR(projects.public = true)) AND projects.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "raw constraints are always grouped",
+		}.Build(ququery.Select("users").Context(ctx).Join("projects", "(projects.owner_id = users.id) OR(projects.public = true)")),
+		"joined table with or before a newline": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users INNER JOIN projects ON (projects.owner_id = users.id OR projects.public = true) AND projects.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "an or on its own line is grouped too",
+		}.Build(ququery.Select("users").Context(ctx).Join("projects", "projects.owner_id = users.id OR\n projects.public = true")),
+		"join on clause": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users INNER JOIN tasks ON tasks.owner_id = users.id AND tasks.done = $1 AND tasks.tenant_id = $2",
+			ExpectedArgs: []any{false, 7},
+			Doc:          "join clauses are guarded too",
+		}.Build(ququery.Select("users").Context(ctx).JoinOn("tasks", func(j *ququery.JoinClause) {
+			j.On("tasks.owner_id", "=", "users.id").OnWhere("tasks.done", "=", false)
+		})),
+		"left join": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users LEFT JOIN projects ON (projects.owner_id = users.id) AND projects.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "left joins are guarded in the join",
+		}.Build(ququery.Select("users").Context(ctx).LeftJoin("projects", "projects.owner_id = users.id")),
+		"right join": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users RIGHT JOIN projects ON projects.owner_id = users.id WHERE projects.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "right joins keep every joined row, so they are guarded in where",
+		}.Build(ququery.Select("users").Context(ctx).RightJoin("projects", "projects.owner_id = users.id")),
+		"full join": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users FULL OUTER JOIN projects ON projects.owner_id = users.id WHERE active = $1 AND projects.tenant_id = $2",
+			ExpectedArgs: []any{true, 7},
+			Doc:          "full joins are guarded in where",
+		}.Build(ququery.Select("users").Context(ctx).FullJoin("projects", "projects.owner_id = users.id").Where("active"), true),
+		"using join": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users INNER JOIN projects USING (owner_id) WHERE projects.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "using joins are guarded in where",
+		}.Build(ququery.Select("users").Context(ctx).JoinUsing("projects", "owner_id")),
+		"cross join": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users CROSS JOIN projects WHERE projects.tenant_id = $1",
+			ExpectedArgs: []any{7},
+			Doc:          "joins without constraints are guarded in where",
+		}.Build(ququery.Select("users").Context(ctx).CrossJoin("projects")),
+		"subquery": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE id IN (SELECT owner_id FROM tasks WHERE tasks.tenant_id = $1)",
+			ExpectedArgs: []any{7},
+			Doc:          "subqueries use the tenant of the outer query",
+		}.Build(ququery.Select("users").Context(ctx).WhereInSub("id", ququery.Select("tasks").Columns("owner_id"))),
+		"update": testutil.Testcase{
+			ExpectedSQL:  "UPDATE projects SET name = $1 WHERE id = $2 AND projects.tenant_id = $3",
+			ExpectedArgs: []any{"website", 1, 7},
+			Doc:          "update the rows of the tenant",
+		}.Build(ququery.Update("projects").Context(ctx).Set("name").Where("id"), "website", 1),
+		"delete": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM tasks WHERE id = $1 AND tasks.tenant_id = $2",
+			ExpectedArgs: []any{1, 7},
+			Doc:          "delete the rows of the tenant",
+		}.Build(ququery.Delete("tasks").Context(ctx).Where("id"), 1),
+		"exists": testutil.Testcase{
+			ExpectedSQL:  "SELECT EXISTS(SELECT true FROM tasks WHERE tasks.tenant_id = $1)",
+			ExpectedArgs: []any{7},
+			Doc:          "check the rows of the tenant",
+		}.Build(ququery.Exists("tasks").Context(ctx)),
+		"insert": testutil.Testcase{
+			ExpectedSQL:  "INSERT INTO projects (name, tenant_id) VALUES ($1,$2)",
+			ExpectedArgs: []any{"website", 7},
+			Doc:          "insert sets the tenant column",
+		}.Build(ququery.Insert("projects").Into("name").Context(ctx), "website"),
+		"pivot attach": testutil.Testcase{
+			ExpectedSQL:  "INSERT INTO project_user (user_id, project_id, tenant_id) VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT DO NOTHING",
+			ExpectedArgs: []any{3, 1, 7, 3, 2, 7},
+			Doc:          "attach sets the tenant column of every row",
+		}.Build(members.Attach(3, 1, 2)),
+		"pivot detach": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM project_user WHERE user_id = $1 AND project_id IN ($2) AND project_user.tenant_id = $3",
+			ExpectedArgs: []any{3, 1, 7},
+			Doc:          "detach deletes the rows of the tenant",
+		}.Build(members.Detach(3, 1)),
+		"pivot sync": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM project_user WHERE user_id = $1 AND project_id NOT IN ($2, $3) AND project_user.tenant_id = $4",
+			ExpectedArgs: []any{3, 1, 2, 7},
+			Doc:          "sync deletes the rows of the tenant",
+		}.Build(detach),
+		"pivot sync attach": testutil.Testcase{
+			ExpectedSQL:  "INSERT INTO project_user (user_id, project_id, tenant_id) VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT DO NOTHING",
+			ExpectedArgs: []any{3, 1, 7, 3, 2, 7},
+			Doc:          "sync attaches the rows with the tenant",
+		}.Build(attach),
+		"other tables": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE id = $1",
+			ExpectedArgs: []any{1},
+			Doc:          "tables not shared by the tenants aren't guarded",
+		}.Build(ququery.Select("users").Where("id"), 1),
+		"missing tenant": testutil.Testcase{
+			ExpectedErr: ququery.ErrMissingTenant,
+			Doc:         "a tenant table needs a tenant in the context",
+		}.Build(ququery.Select("projects").Context(context.Background())),
+		"missing tenant in join": testutil.Testcase{
+			ExpectedErr: ququery.ErrMissingTenant,
+			Doc:         "joined tenant tables need a tenant too",
+		}.Build(ququery.Select("users").Join("tasks", "tasks.owner_id = users.id")),
+		"missing tenant in insert": testutil.Testcase{
+			ExpectedErr: ququery.ErrMissingTenant,
+			Doc:         "insert without a context",
+		}.Build(ququery.Insert("tasks").Into("title"), "docs"),
+		"missing tenant in pivot attach": testutil.Testcase{
+			ExpectedErr: ququery.ErrMissingTenant,
+			Doc:         "attach without a context",
+		}.Build(ququery.Pivot("project_user", "user_id", "project_id").Attach(3, 1)),
+		"missing tenant in pivot detach": testutil.Testcase{
+			ExpectedErr: ququery.ErrMissingTenant,
+			Doc:         "detach without a context",
+		}.Build(ququery.Pivot("project_user", "user_id", "project_id").Detach(3)),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	// The SQL returned by a subquery callback can't carry the tenant.
+	callback := ququery.Select("users").Context(ctx).WhereInSubquery("id", func(q ququery.SelectQuery) string {
+		return q.Table("tasks").Columns("owner_id").Query()
+	})
+
+	if _, _, err := callback.Build(); err == nil {
+		t.Error("expected an error for a subquery callback on a tenant table")
+	}
+}
+
+func TestTenantTables_InsertTenantColumn(t *testing.T) {
+	ctx := ququery.WithTenant(context.Background(), 7)
+
+	queries := map[string]testutil.Builder{
+		"insert":       ququery.Insert("projects").Into("name", "tenant_id").Context(ctx),
+		"pivot attach": ququery.Pivot("project_user", "user_id", "project_id").Columns("tenant_id").Context(ctx).Attach(3, 1),
+	}
+
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := q.Build("website", 8); err == nil {
+				t.Fatal("error: got nil for an insert setting the tenant column")
+			}
+		})
+	}
+}
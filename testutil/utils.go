@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -17,6 +18,20 @@ type Testcase struct {
 	Doc          string
 	Query        string
 	ExpectedArgs []any
+	Args         []any
+	ExpectedErr  error
+	Err          error
+}
+
+type Builder interface {
+	Build(values ...any) (string, []any, error)
+}
+
+// Build fills Query, Args and Err of the testcase from the builder.
+func (tc Testcase) Build(b Builder, values ...any) Testcase {
+	tc.Query, tc.Args, tc.Err = b.Build(values...)
+
+	return tc
 }
 
 var (
@@ -65,6 +80,14 @@ func RunTests(t *testing.T, cases Testcases, format FormatFunc) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			if tc.Err != nil || tc.ExpectedErr != nil {
+				if !errors.Is(tc.Err, tc.ExpectedErr) {
+					t.Fatalf("error: got %v, want %v", tc.Err, tc.ExpectedErr)
+				}
+
+				return
+			}
+
 			diff, err := QueryDiff(tc.ExpectedSQL, tc.Query, format)
 			if err != nil {
 				t.Fatalf("error: %v", err)
@@ -74,6 +97,12 @@ func RunTests(t *testing.T, cases Testcases, format FormatFunc) {
 				fmt.Println(tc.Query)
 				t.Fatalf("diff: %s", diff)
 			}
+
+			if tc.ExpectedArgs != nil {
+				if diff := ArgsDiff(tc.ExpectedArgs, tc.Args); diff != "" {
+					t.Fatalf("args diff: %s", diff)
+				}
+			}
 		})
 	}
 }
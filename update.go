@@ -9,6 +9,7 @@ import (
 type UpdateQuery struct {
 	table   string
 	columns []string
+	restore bool
 	WhereContainer[*UpdateQuery]
 }
 
@@ -25,7 +26,28 @@ func (q *UpdateQuery) Set(columns ...string) *UpdateQuery {
 	return q
 }
 
-func (q *UpdateQuery) Query() string {
+func (q *UpdateQuery) build() (string, []any, error) {
+	where, whereArgs, err := prepareWhereQuery(tenantConditions(q.softDeleteConditions(q.table, q.trashed), q.table), q.env())
+	if err != nil {
+		return "", nil, err
+	}
+
+	set := prepareUpdateQuery(q.columns)
+	args := append(placeholders(set), whereArgs...)
+
+	if q.restore {
+		column, ok := softDeleteColumn(q.table)
+		if !ok {
+			return "", nil, fmt.Errorf("ququery: can't restore %s, it isn't soft deleted", q.table)
+		}
+
+		if set != "" {
+			set += ","
+		}
+
+		set += fmt.Sprintf(" %s = NULL", column)
+	}
+
 	query := fmt.Sprintf(
 		`
 			UPDATE %s 
@@ -33,11 +55,40 @@ func (q *UpdateQuery) Query() string {
 			%s
 		`,
 		q.table,
-		prepareUpdateQuery(q.columns),
-		prepareWhereQuery(q.conditions),
+		set,
+		where,
 	)
 
-	return sqlx.Rebind(sqlx.DOLLAR, query)
+	return sqlx.Rebind(q.dialect.bindType(), query), args, nil
+}
+
+// Query returns the SQL of the query, or an empty string when the query can't be built.
+//
+// Deprecated: Query hides build errors, and some drivers run an empty query as a no-op.
+// Use Build, which returns the build error and the bound arguments.
+func (q *UpdateQuery) Query() string {
+	query, _, err := q.build()
+	if err != nil {
+		return ""
+	}
+
+	return query
+}
+
+// Build returns the SQL of the query with its arguments in placeholder order.
+// Values are used for the placeholders whose value is not bound by the builder, in order.
+func (q *UpdateQuery) Build(values ...any) (string, []any, error) {
+	query, args, err := q.build()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args, err = bindArgs(args, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return query, args, nil
 }
 
 func prepareUpdateQuery(columns []string) string {
@@ -22,3 +22,24 @@ func TestUpdateQuery_Update(t *testing.T) {
 
 	testutil.RunTests(t, testcases, nil)
 }
+
+func TestUpdateQuery_When(t *testing.T) {
+	testcases := testutil.Testcases{
+		"update with optional set columns": testutil.Testcase{
+			Query: ququery.Update("users").
+				Set("email").
+				When(true, func(q *ququery.UpdateQuery) *ququery.UpdateQuery {
+					return q.Set("name")
+				}).
+				Unless(true, func(q *ququery.UpdateQuery) *ququery.UpdateQuery {
+					return q.Set("phone")
+				}).
+				Where("id").
+				Query(),
+			ExpectedSQL: "UPDATE users SET email = $1, name = $2 WHERE id = $3",
+			Doc:         "update only the columns that were sent",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
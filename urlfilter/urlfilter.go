@@ -0,0 +1,271 @@
+// Package urlfilter applies the filters, sorting and pagination requested in
+// an HTTP query string to a select query, like
+//
+//	?filter[age][gte]=30&filter[status][in]=a,b&sort=-created_at,name&page[number]=2&page[size]=20
+//
+// Only the fields, operators and sorts of the endpoint's Config are accepted,
+// and every value is bound to the query.
+package urlfilter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adel-hadadi/ququery"
+)
+
+// Config is the allowlist of an endpoint.
+type Config struct {
+	// Filters are the fields that can be filtered and their operators.
+	Filters ququery.Allowlist
+
+	// Sorts maps the public names accepted by the sort parameter to columns.
+	Sorts map[string]string
+
+	// DefaultPageSize is used when page[size] isn't given. Zero leaves the query
+	// unpaginated unless the client asks for a page.
+	DefaultPageSize int
+
+	// MaxPageSize is the largest page[size] accepted. Zero means no limit.
+	MaxPageSize int
+}
+
+// Error is returned for a query string parameter that can't be applied.
+// It is a client error and can be mapped to a 400 response.
+type Error struct {
+	Param  string
+	Reason string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("urlfilter: %s: %s", e.Param, e.Reason)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type sortColumn struct {
+	column    string
+	direction string
+}
+
+// Apply applies the filters, sorting and pagination of values to q. When any of them is
+// invalid, an *Error is returned and q is left untouched. A requested sort replaces the
+// sort of q, so q can be sorted by default.
+//
+// Example:
+//
+//	values, _ := url.ParseQuery("filter[age][gte]=30&sort=-created_at&page[size]=20")
+//	q, err := urlfilter.Apply(ququery.Select("users"), values, config)
+//	query, args, err := q.Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE users.age >= $1 ORDER BY users.created_at DESC LIMIT $2 OFFSET $3 [30 20 0]
+func Apply(q *ququery.SelectQuery, values url.Values, config Config) (*ququery.SelectQuery, error) {
+	filters, err := parseFilters(values)
+	if err != nil {
+		return q, err
+	}
+
+	sorts, err := parseSort(values, config.Sorts)
+	if err != nil {
+		return q, err
+	}
+
+	page, size, err := parsePage(values, config)
+	if err != nil {
+		return q, err
+	}
+
+	if _, err := ququery.ApplyFilters(q, filters, config.Filters); err != nil {
+		return q, filterError(err)
+	}
+
+	for i, s := range sorts {
+		if i == 0 {
+			q.OrderBy(s.column, s.direction)
+			continue
+		}
+
+		q.ThenBy(s.column, s.direction)
+	}
+
+	if size > 0 {
+		q.Paginate(page, size)
+	}
+
+	return q, nil
+}
+
+// parseFilters reads filter[field] and filter[field][operator] parameters.
+func parseFilters(values url.Values) (map[string]any, error) {
+	filters := map[string]any{}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path, ok := bracketPath(key, "filter")
+		if !ok {
+			continue
+		}
+
+		if len(path) == 0 || len(path) > 2 || path[0] == "" {
+			return nil, &Error{Param: key, Reason: "expected filter[field] or filter[field][operator]"}
+		}
+
+		op := "eq"
+		if len(path) == 2 {
+			op = path[1]
+		}
+
+		value, err := filterValue(op, values[key])
+		if err != nil {
+			return nil, &Error{Param: key, Reason: err.Error()}
+		}
+
+		ops, _ := filters[path[0]].(map[string]any)
+		if ops == nil {
+			ops = map[string]any{}
+			filters[path[0]] = ops
+		}
+
+		ops[op] = value
+	}
+
+	return filters, nil
+}
+
+// filterValue splits the comma separated lists of the operators that take several values.
+func filterValue(op string, raw []string) (any, error) {
+	if len(raw) != 1 {
+		return nil, errors.New("expected a single value")
+	}
+
+	switch op {
+	case "in", "nin", "between":
+		parts := strings.Split(raw[0], ",")
+
+		list := make([]any, len(parts))
+		for i, part := range parts {
+			list[i] = part
+		}
+
+		return list, nil
+	}
+
+	return raw[0], nil
+}
+
+// parseSort reads the comma separated sort parameter. A leading "-" sorts descending.
+func parseSort(values url.Values, allowed map[string]string) ([]sortColumn, error) {
+	raw, ok := values["sort"]
+	if !ok {
+		return nil, nil
+	}
+
+	if len(raw) != 1 {
+		return nil, &Error{Param: "sort", Reason: "expected a single value"}
+	}
+
+	var sorts []sortColumn
+
+	for _, name := range strings.Split(raw[0], ",") {
+		direction := ququery.ASC
+		if strings.HasPrefix(name, "-") {
+			direction, name = ququery.DESC, name[1:]
+		}
+
+		column, ok := allowed[name]
+		if !ok {
+			return nil, &Error{Param: "sort", Reason: fmt.Sprintf("unknown sort %q", name)}
+		}
+
+		sorts = append(sorts, sortColumn{column: column, direction: direction})
+	}
+
+	return sorts, nil
+}
+
+// parsePage reads page[number] and page[size]. A zero size means no pagination.
+func parsePage(values url.Values, config Config) (int, int, error) {
+	page, size := 1, config.DefaultPageSize
+
+	for key, raw := range values {
+		path, ok := bracketPath(key, "page")
+		if !ok {
+			continue
+		}
+
+		if len(path) != 1 || (path[0] != "number" && path[0] != "size") {
+			return 0, 0, &Error{Param: key, Reason: "expected page[number] or page[size]"}
+		}
+
+		if len(raw) != 1 {
+			return 0, 0, &Error{Param: key, Reason: "expected a single value"}
+		}
+
+		n, err := strconv.Atoi(raw[0])
+		if err != nil || n < 1 {
+			return 0, 0, &Error{Param: key, Reason: "expected a positive integer", Err: err}
+		}
+
+		if path[0] == "number" {
+			page = n
+		} else {
+			size = n
+		}
+	}
+
+	if config.MaxPageSize > 0 && size > config.MaxPageSize {
+		return 0, 0, &Error{Param: "page[size]", Reason: fmt.Sprintf("must be at most %d", config.MaxPageSize)}
+	}
+
+	if size == 0 && page > 1 {
+		return 0, 0, &Error{Param: "page[number]", Reason: "page[size] is required"}
+	}
+
+	return page, size, nil
+}
+
+// bracketPath splits "name[a][b]" into [a b] when the key starts with name.
+func bracketPath(key, name string) ([]string, bool) {
+	if key == name {
+		return nil, true
+	}
+
+	rest, ok := strings.CutPrefix(key, name+"[")
+	if !ok || !strings.HasSuffix(rest, "]") {
+		return nil, false
+	}
+
+	return strings.Split(strings.TrimSuffix(rest, "]"), "]["), true
+}
+
+// filterError converts the errors of ququery.ApplyFilters to an *Error.
+func filterError(err error) error {
+	var (
+		unknown  *ququery.UnknownFilterError
+		operator *ququery.FilterOperatorError
+		value    *ququery.FilterValueError
+	)
+
+	switch {
+	case errors.As(err, &unknown):
+		return &Error{Param: fmt.Sprintf("filter[%s]", unknown.Field), Reason: "unknown filter", Err: err}
+	case errors.As(err, &operator):
+		return &Error{Param: fmt.Sprintf("filter[%s][%s]", operator.Field, operator.Operator), Reason: "operator not allowed", Err: err}
+	case errors.As(err, &value):
+		return &Error{Param: fmt.Sprintf("filter[%s][%s]", value.Field, value.Operator), Reason: value.Reason, Err: err}
+	}
+
+	return err
+}
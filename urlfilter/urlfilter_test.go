@@ -0,0 +1,118 @@
+package urlfilter_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/adel-hadadi/ququery"
+	"github.com/adel-hadadi/ququery/testutil"
+	"github.com/adel-hadadi/ququery/urlfilter"
+)
+
+var config = urlfilter.Config{
+	Filters: ququery.Allowlist{
+		"age":     {Column: "users.age", Operators: []string{"gte", "lte", "between"}},
+		"deleted": {Column: "users.deleted_at", Operators: []string{"null"}},
+		"status":  {Column: "users.status", Operators: []string{"eq", "in"}},
+	},
+	Sorts: map[string]string{
+		"created_at": "users.created_at",
+		"name":       "users.name",
+	},
+	MaxPageSize: 50,
+}
+
+func apply(t *testing.T, rawQuery string, config urlfilter.Config) *ququery.SelectQuery {
+	t.Helper()
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatalf("parse query: %v", err)
+	}
+
+	q, err := urlfilter.Apply(ququery.Select("users"), values, config)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	return q
+}
+
+func TestApply(t *testing.T) {
+	withDefaultPage := config
+	withDefaultPage.DefaultPageSize = 10
+
+	sorted, err := urlfilter.Apply(ququery.Select("users").OrderBy("users.id", ququery.ASC), url.Values{"sort": {"name"}}, config)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	testcases := testutil.Testcases{
+		"sort replaces the default sort": testutil.Testcase{
+			ExpectedSQL: "SELECT * FROM users ORDER BY users.name ASC",
+			Doc:         "the requested sort overrides the sort of the query",
+		}.Build(sorted),
+		"filters, sorting and pagination": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE users.age >= $1 AND users.status IN ($2, $3) ORDER BY users.created_at DESC, users.name ASC LIMIT $4 OFFSET $5",
+			ExpectedArgs: []any{"30", "a", "b", 20, 20},
+			Doc:          "apply every part of the query string",
+		}.Build(apply(t, "filter[age][gte]=30&filter[status][in]=a,b&sort=-created_at,name&page[size]=20&page[number]=2", config)),
+		"filters without operator, between and null": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE users.age BETWEEN $1 AND $2 AND users.deleted_at IS NULL AND users.status = $3",
+			ExpectedArgs: []any{"18", "30", "active"},
+			Doc:          "filter[field] uses the eq operator",
+		}.Build(apply(t, "filter[status]=active&filter[age][between]=18,30&filter[deleted][null]=true", config)),
+		"default page size": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users LIMIT $1 OFFSET $2",
+			ExpectedArgs: []any{10, 0},
+			Doc:          "the default page size applies without page parameters",
+		}.Build(apply(t, "q=ignored", withDefaultPage)),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestApply_Errors(t *testing.T) {
+	testcases := map[string]struct {
+		rawQuery string
+		param    string
+	}{
+		"unknown filter":        {rawQuery: "filter[password]=x", param: "filter[password]"},
+		"operator not allowed":  {rawQuery: "filter[age][ne]=1", param: "filter[age][ne]"},
+		"invalid filter value":  {rawQuery: "filter[age][between]=1", param: "filter[age][between]"},
+		"too deep filter":       {rawQuery: "filter[age][gte][x]=1", param: "filter[age][gte][x]"},
+		"repeated filter":       {rawQuery: "filter[status]=a&filter[status]=b", param: "filter[status]"},
+		"unknown sort":          {rawQuery: "sort=password", param: "sort"},
+		"invalid page size":     {rawQuery: "page[size]=ten", param: "page[size]"},
+		"page size over max":    {rawQuery: "page[size]=500", param: "page[size]"},
+		"unknown page param":    {rawQuery: "page[offset]=5", param: "page[offset]"},
+		"page without its size": {rawQuery: "page[number]=2", param: "page[number]"},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.rawQuery)
+			if err != nil {
+				t.Fatalf("parse query: %v", err)
+			}
+
+			q := ququery.Select("users")
+
+			_, err = urlfilter.Apply(q, values, config)
+
+			var urlErr *urlfilter.Error
+			if !errors.As(err, &urlErr) {
+				t.Fatalf("error: got %v, want *urlfilter.Error", err)
+			}
+
+			if urlErr.Param != tc.param {
+				t.Fatalf("param: got %q, want %q", urlErr.Param, tc.param)
+			}
+
+			if query := q.Query(); query != "SELECT * FROM users" {
+				t.Fatalf("query changed on error: %s", query)
+			}
+		})
+	}
+}
@@ -1,19 +1,11 @@
 package ququery
 
 import (
+	"context"
 	"fmt"
+	"strings"
 )
 
-var allowedOpperators = []string{
-	"=",
-	"!=",
-	">",
-	"<",
-	">=",
-	"<=",
-	"NOT",
-}
-
 type (
 	whereable interface {
 		Query() string
@@ -23,28 +15,55 @@ type (
 	WhereContainer[T whereable] struct {
 		self       T
 		conditions []whereStructure
+		dialect    Dialect
+		trashed    trashedMode
+		ctx        context.Context
 	}
 )
 
-func (c *WhereContainer[T]) checkOperator(column []string) string {
-	op := "="
-	if len(column) == 1 {
-		return op
-	}
+// Dialect sets the SQL dialect the query is rendered for. Placeholders and
+// dialect specific helpers such as WhereDate follow the selected dialect.
+//
+// Example:
+//
+//	query := ququery.Select("users").Dialect(ququery.MySQL).WhereYear("created_at").Query()
+//	log.Println(query) => SELECT * FROM users WHERE YEAR(created_at) = ?
+func (c *WhereContainer[T]) Dialect(dialect Dialect) T {
+	c.dialect = dialect
 
-	opExist := false
+	return c.self
+}
 
-	for _, v := range allowedOpperators {
-		if v == column[1] {
-			opExist = true
-		}
+// When calls f with the query when cond is true, so optional clauses don't break the chain.
+//
+// Example:
+//
+//	query := ququery.Select("users").
+//		When(req.Name != "", func(q *ququery.SelectQuery) *ququery.SelectQuery {
+//			return q.WhereContains("name", req.Name)
+//		}).
+//		Query()
+func (c *WhereContainer[T]) When(cond bool, f func(q T) T) T {
+	if cond {
+		return f(c.self)
 	}
 
-	if !opExist {
-		return op
+	return c.self
+}
+
+// Unless calls f with the query when cond is false.
+func (c *WhereContainer[T]) Unless(cond bool, f func(q T) T) T {
+	return c.When(!cond, f)
+}
+
+// checkOperator returns the operator passed after the column, or "=" when there is none.
+// Operators are validated against the dialect when the query is built.
+func (c *WhereContainer[T]) checkOperator(column []string) string {
+	if len(column) == 1 {
+		return "="
 	}
 
-	return column[1]
+	return normalizeOperator(column[1])
 }
 
 // Where You may use the query builder's Where method to add "where" clauses to the query.
@@ -145,6 +164,132 @@ func (c *WhereContainer[T]) OrWhereNull(column string) T {
 	return c.self
 }
 
+// WhereIn method verifies that the column's value is one of the given values.
+// The values are bound to the query. When there are no values the condition never matches.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").WhereIn("role_id", 1, 2, 3).Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE role_id IN ($1, $2, $3) [1 2 3]
+func (c *WhereContainer[T]) WhereIn(column string, values ...any) T {
+	return c.whereIn(column, values, false, true)
+}
+
+// OrWhereIn method allows you to add an "or" clause to WhereIn condition.
+func (c *WhereContainer[T]) OrWhereIn(column string, values ...any) T {
+	return c.whereIn(column, values, false, false)
+}
+
+// WhereNotIn method verifies that the column's value is not one of the given values.
+// When there are no values the condition always matches.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").WhereNotIn("status", "banned", "deleted").Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE status NOT IN ($1, $2) [banned deleted]
+func (c *WhereContainer[T]) WhereNotIn(column string, values ...any) T {
+	return c.whereIn(column, values, true, true)
+}
+
+// OrWhereNotIn method allows you to add an "or" clause to WhereNotIn condition.
+func (c *WhereContainer[T]) OrWhereNotIn(column string, values ...any) T {
+	return c.whereIn(column, values, true, false)
+}
+
+func (c *WhereContainer[T]) whereIn(column string, values []any, not, isAnd bool) T {
+	var query string
+
+	switch {
+	case len(values) == 0 && not:
+		query = "1 = 1"
+	case len(values) == 0:
+		query = "1 = 0"
+	case not:
+		query = fmt.Sprintf("%s NOT IN (%s)", column, strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "))
+	default:
+		query = fmt.Sprintf("%s IN (%s)", column, strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "))
+	}
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd:    isAnd,
+		isRaw:    true,
+		rawQuery: query,
+		args:     append([]any{}, values...),
+	})
+
+	return c.self
+}
+
+// WhereBetween method verifies that the column's value is between two values.
+// The values are bound to the query.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").WhereBetween("age", 18, 30).Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE age BETWEEN $1 AND $2 [18 30]
+func (c *WhereContainer[T]) WhereBetween(column string, from, to any) T {
+	return c.whereBetween(column, from, to, false, true)
+}
+
+// OrWhereBetween method allows you to add an "or" clause to WhereBetween condition.
+func (c *WhereContainer[T]) OrWhereBetween(column string, from, to any) T {
+	return c.whereBetween(column, from, to, false, false)
+}
+
+// WhereNotBetween method verifies that the column's value lies outside of two values.
+func (c *WhereContainer[T]) WhereNotBetween(column string, from, to any) T {
+	return c.whereBetween(column, from, to, true, true)
+}
+
+// OrWhereNotBetween method allows you to add an "or" clause to WhereNotBetween condition.
+func (c *WhereContainer[T]) OrWhereNotBetween(column string, from, to any) T {
+	return c.whereBetween(column, from, to, true, false)
+}
+
+func (c *WhereContainer[T]) whereBetween(column string, from, to any, not, isAnd bool) T {
+	query := column + " BETWEEN ? AND ?"
+	if not {
+		query = column + " NOT BETWEEN ? AND ?"
+	}
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd:    isAnd,
+		isRaw:    true,
+		rawQuery: query,
+		args:     []any{from, to},
+	})
+
+	return c.self
+}
+
+// WhereValue method compares the column with a value bound to the query,
+// so it doesn't need to be passed to Build.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("posts").WhereValue("user_id", "=", 7).Build()
+//	log.Println(query, args) => SELECT * FROM posts WHERE user_id = $1 [7]
+func (c *WhereContainer[T]) WhereValue(column, operator string, value any) T {
+	return c.whereValue(column, operator, value, true)
+}
+
+// OrWhereValue method allows you to add an "or" clause to WhereValue condition.
+func (c *WhereContainer[T]) OrWhereValue(column, operator string, value any) T {
+	return c.whereValue(column, operator, value, false)
+}
+
+// whereValue adds a "column operator ?" condition with a value bound to the query.
+func (c *WhereContainer[T]) whereValue(column, operator string, value any, isAnd bool) T {
+	c.conditions = append(c.conditions, whereStructure{
+		column:   column,
+		operator: normalizeOperator(operator),
+		args:     []any{value},
+		isAnd:    isAnd,
+	})
+
+	return c.self
+}
+
 // WhereLike method allows you to add "LIKE" clauses to your query from pattern matchinga.
 //
 // Example:
@@ -177,6 +322,176 @@ func (c *WhereContainer[T]) OrWhereLike(column string) T {
 	return c.self
 }
 
+// WhereNotLike method allows you to add "NOT LIKE" clauses to your query.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereNotLike("email").Query()
+//	log.Println(query) => SELECT * FROM users WHERE email NOT LIKE $1
+func (c *WhereContainer[T]) WhereNotLike(column string) T {
+	c.conditions = append(c.conditions, whereStructure{
+		column:   column,
+		operator: "NOT LIKE",
+		isAnd:    true,
+	})
+
+	return c.self
+}
+
+// OrWhereNotLike method allows you to add an "or" clause with a NOT LIKE condition
+func (c *WhereContainer[T]) OrWhereNotLike(column string) T {
+	c.conditions = append(c.conditions, whereStructure{
+		column:   column,
+		operator: "NOT LIKE",
+		isAnd:    false,
+	})
+
+	return c.self
+}
+
+// WhereILike method allows you to add case-insensitive "LIKE" clauses to your query.
+// It renders ILIKE on PostgreSQL and compares lower cased values on other dialects.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereILike("name").Query()
+//	log.Println(query) => SELECT * FROM users WHERE name ILIKE $1
+//
+//	query = ququery.Select("users").Dialect(ququery.MySQL).WhereILike("name").Query()
+//	log.Println(query) => SELECT * FROM users WHERE LOWER(name) LIKE LOWER(?)
+func (c *WhereContainer[T]) WhereILike(column string) T {
+	return c.whereILike(column, false, true)
+}
+
+// OrWhereILike method allows you to add an "or" clause with a case-insensitive LIKE condition
+func (c *WhereContainer[T]) OrWhereILike(column string) T {
+	return c.whereILike(column, false, false)
+}
+
+// WhereNotILike method allows you to add case-insensitive "NOT LIKE" clauses to your query.
+func (c *WhereContainer[T]) WhereNotILike(column string) T {
+	return c.whereILike(column, true, true)
+}
+
+// OrWhereNotILike method allows you to add an "or" clause with a case-insensitive NOT LIKE condition
+func (c *WhereContainer[T]) OrWhereNotILike(column string) T {
+	return c.whereILike(column, true, false)
+}
+
+func (c *WhereContainer[T]) whereILike(column string, not, isAnd bool) T {
+	operator := "LIKE"
+	if not {
+		operator = "NOT LIKE"
+	}
+
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		render: func(dialect Dialect) (string, error) {
+			if dialect == PostgreSQL {
+				return fmt.Sprintf("%s %s ?", column, strings.Replace(operator, "LIKE", "ILIKE", 1)), nil
+			}
+
+			return fmt.Sprintf("LOWER(%s) %s LOWER(?)", column, operator), nil
+		},
+	})
+
+	return c.self
+}
+
+// WhereLikeCollate method adds a "LIKE" clause compared with the given collation, so you can
+// choose whether matching is case-sensitive on MySQL. It is only supported on MySQL.
+//
+// Example:
+//
+//	query := ququery.Select("users").Dialect(ququery.MySQL).WhereLikeCollate("name", "utf8mb4_bin").Query()
+//	log.Println(query) => SELECT * FROM users WHERE name LIKE ? COLLATE utf8mb4_bin
+func (c *WhereContainer[T]) WhereLikeCollate(column, collation string) T {
+	return c.whereLikeCollate(column, collation, true)
+}
+
+// OrWhereLikeCollate method allows you to add an "or" clause to WhereLikeCollate condition.
+func (c *WhereContainer[T]) OrWhereLikeCollate(column, collation string) T {
+	return c.whereLikeCollate(column, collation, false)
+}
+
+func (c *WhereContainer[T]) whereLikeCollate(column, collation string, isAnd bool) T {
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		render: func(dialect Dialect) (string, error) {
+			if dialect != MySQL {
+				return "", fmt.Errorf("%w: LIKE collation on %s", ErrUnsupported, dialect)
+			}
+
+			return fmt.Sprintf("%s LIKE ? COLLATE %s", column, collation), nil
+		},
+	})
+
+	return c.self
+}
+
+// WhereContains method matches the rows where the column contains term.
+// The term is bound to the query with its "%", "_" and "\" characters escaped,
+// so user input is always matched literally.
+//
+// Example:
+//
+//	query, args, err := ququery.Select("users").WhereContains("name", "50%").Build()
+//	log.Println(query, args) => SELECT * FROM users WHERE name LIKE $1 ESCAPE '\' [%50\%%]
+func (c *WhereContainer[T]) WhereContains(column, term string) T {
+	return c.whereEscapedLike(column, "%"+escapeLike(term)+"%", true)
+}
+
+// OrWhereContains method allows you to add an "or" clause to WhereContains condition.
+func (c *WhereContainer[T]) OrWhereContains(column, term string) T {
+	return c.whereEscapedLike(column, "%"+escapeLike(term)+"%", false)
+}
+
+// WhereStartsWith method matches the rows where the column starts with term.
+// Like WhereContains the term is escaped and bound to the query.
+func (c *WhereContainer[T]) WhereStartsWith(column, term string) T {
+	return c.whereEscapedLike(column, escapeLike(term)+"%", true)
+}
+
+// OrWhereStartsWith method allows you to add an "or" clause to WhereStartsWith condition.
+func (c *WhereContainer[T]) OrWhereStartsWith(column, term string) T {
+	return c.whereEscapedLike(column, escapeLike(term)+"%", false)
+}
+
+// WhereEndsWith method matches the rows where the column ends with term.
+// Like WhereContains the term is escaped and bound to the query.
+func (c *WhereContainer[T]) WhereEndsWith(column, term string) T {
+	return c.whereEscapedLike(column, "%"+escapeLike(term), true)
+}
+
+// OrWhereEndsWith method allows you to add an "or" clause to WhereEndsWith condition.
+func (c *WhereContainer[T]) OrWhereEndsWith(column, term string) T {
+	return c.whereEscapedLike(column, "%"+escapeLike(term), false)
+}
+
+func (c *WhereContainer[T]) whereEscapedLike(column, pattern string, isAnd bool) T {
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		args:  []any{pattern},
+		render: func(dialect Dialect) (string, error) {
+			// MySQL reads backslashes in string literals as escapes too.
+			if dialect == MySQL {
+				return fmt.Sprintf(`%s LIKE ? ESCAPE '\\'`, column), nil
+			}
+
+			return fmt.Sprintf(`%s LIKE ? ESCAPE '\'`, column), nil
+		},
+	})
+
+	return c.self
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapes the LIKE wildcards of s with backslashes.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 // Strpos method is more like whereLike method,
 // but the difference is that strpos method is used by postgresql users for full text search.
 //
@@ -214,24 +529,124 @@ func (c *WhereContainer[T]) OrStrpos(column string) T {
 // parentheses in order to achieve your query's desired logical grouping.
 // In fact, you should generally always group calls to the orWhere method in
 // parentheses in order to avoid unexpected query behavior.
-// To accomplish this, you may user this method:
+// To accomplish this, you may user this method. The group has every condition
+// method of the query, including WhereGroup itself, and keeps its bound arguments:
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereGroup(func(q *ququery.MultiWhere) {
+//		q.Where("email").
+//			OrWhere("role_id").
+//			WhereGroup(func(q *ququery.MultiWhere) {
+//				q.Where("type").OrWhereNull("deleted_at")
+//			})
+//	}).Query()
+//	log.Println(query) => SELECT * FROM users WHERE (email = $1 OR role_id = $2 AND (type = $3 OR deleted_at IS NULL))
+func (c *WhereContainer[T]) WhereGroup(f func(q *MultiWhere)) T {
+	return c.whereGroup(f, false, true)
+}
+
+// OrWhereGroup method allows you to add an "or" clause to WhereGroup condition.
+//
+// Example:
+//
+//	query := ququery.Select("users").Where("role").OrWhereGroup(func(q *ququery.MultiWhere) {
+//		q.Where("role").Where("team_id")
+//	}).Query()
+//	log.Println(query) => SELECT * FROM users WHERE role = $1 OR (role = $2 AND team_id = $3)
+func (c *WhereContainer[T]) OrWhereGroup(f func(q *MultiWhere)) T {
+	return c.whereGroup(f, false, false)
+}
+
+// WhereNotGroup method negates a group of "where" clauses.
 //
 // Example:
 //
-//		     query := ququery.Select("users").WhereGroup(func(subQuery ququery.MultiWhere) string {
-//			    return subQuery.Where("email").
-//				    Where("role_id").
-//				    OrWhere("type").
-//				    Query()
-//		    }).Query(),
-//	        log.Println(query) => SELECT * FROM users WHERE ( email = $1 AND role_id = $2 OR type = $3)
-func (c *WhereContainer[T]) WhereGroup(f func(subQuery MultiWhere) string) T {
-	query := f(MultiWhere{})
+//	query := ququery.Select("users").WhereNotGroup(func(q *ququery.MultiWhere) {
+//		q.Where("banned").OrWhereNotNull("deleted_at")
+//	}).Query()
+//	log.Println(query) => SELECT * FROM users WHERE NOT (banned = $1 OR deleted_at IS NOT NULL)
+func (c *WhereContainer[T]) WhereNotGroup(f func(q *MultiWhere)) T {
+	return c.whereGroup(f, true, true)
+}
+
+// OrWhereNotGroup method allows you to add an "or" clause to WhereNotGroup condition.
+func (c *WhereContainer[T]) OrWhereNotGroup(f func(q *MultiWhere)) T {
+	return c.whereGroup(f, true, false)
+}
+
+func (c *WhereContainer[T]) whereGroup(f func(q *MultiWhere), isNot, isAnd bool) T {
+	group := newMultiWhere(c.dialect)
+	f(group)
+
+	if len(group.conditions) == 0 {
+		return c.self
+	}
 
 	c.conditions = append(c.conditions, whereStructure{
-		isAnd:    true,
-		isRaw:    true,
-		rawQuery: query,
+		isAnd: isAnd,
+		isNot: isNot,
+		group: group.conditions,
+	})
+
+	return c.self
+}
+
+// WhereColumn method compares two columns, like the columns of a subquery and its outer query.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereColumn("updated_at", ">", "created_at").Query()
+//	log.Println(query) => SELECT * FROM users WHERE updated_at > created_at
+func (c *WhereContainer[T]) WhereColumn(first, operator, second string) T {
+	return c.whereColumn(first, operator, second, true)
+}
+
+// OrWhereColumn method allows you to add an "or" clause to WhereColumn condition.
+func (c *WhereContainer[T]) OrWhereColumn(first, operator, second string) T {
+	return c.whereColumn(first, operator, second, false)
+}
+
+func (c *WhereContainer[T]) whereColumn(first, operator, second string, isAnd bool) T {
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		args:  []any{},
+		render: func(dialect Dialect) (string, error) {
+			return compareColumns(dialect, first, normalizeOperator(operator), second)
+		},
+	})
+
+	return c.self
+}
+
+// WhereInSub method verifies that the column's value is in the results of a subquery.
+// The subquery is rendered with the query's dialect and its arguments are merged in.
+//
+// Example:
+//
+//	orders := ququery.Select("orders").Columns("user_id").Where("total", ">")
+//	query, args, err := ququery.Select("users").Where("active").WhereInSub("users.id", orders).Build(true, 100)
+//	log.Println(query, args) => SELECT * FROM users WHERE active = $1 AND users.id IN (SELECT user_id FROM orders WHERE total > $2) [true 100]
+func (c *WhereContainer[T]) WhereInSub(column string, sub *SelectQuery) T {
+	return c.whereInSub(column, sub, true)
+}
+
+// OrWhereInSub method allows you to add an "or" clause to WhereInSub condition.
+func (c *WhereContainer[T]) OrWhereInSub(column string, sub *SelectQuery) T {
+	return c.whereInSub(column, sub, false)
+}
+
+func (c *WhereContainer[T]) whereInSub(column string, sub *SelectQuery, isAnd bool) T {
+	c.conditions = append(c.conditions, whereStructure{
+		isAnd: isAnd,
+		build: func(e env) (string, []any, error) {
+			query, args, err := sub.prepareSelectQuery(e)
+			if err != nil {
+				return "", nil, err
+			}
+
+			return fmt.Sprintf("%s IN (%s)", column, query), args, nil
+		},
 	})
 
 	return c.self
@@ -239,7 +654,9 @@ func (c *WhereContainer[T]) WhereGroup(f func(subQuery MultiWhere) string) T {
 
 // WhereInSubquery Sometimes you may need to construct a "where" clause that compares
 // the results of a subquery to a given value. You may accomplish this by
-// passing a closure and a value to the where method.
+// passing a closure and a value to the where method. The closure is called when the query is built,
+// and the query handed to it is rendered with the outer query's dialect and tenant. Its SQL can't
+// carry bound values, so use WhereInSub for subqueries of tenant tables or with bound values.
 //
 // Example:
 //
@@ -254,15 +671,7 @@ func (c *WhereContainer[T]) WhereGroup(f func(subQuery MultiWhere) string) T {
 //
 //	    log.Println(query) => SELECT * FROM users WHERE users.id IN (SELECT user_id FROM orders ORDER BY total_price DESC LIMIT $1)
 func (c *WhereContainer[T]) WhereInSubquery(column string, subQuery func(q SelectQuery) string) T {
-	c.conditions = append(c.conditions, whereStructure{
-		rawQuery: fmt.Sprintf("%s IN (%s)", column, subQuery(SelectQuery{
-			withoutRebinding: true,
-		})),
-		isAnd: true,
-		isRaw: true,
-	})
-
-	return c.self
+	return c.whereInSubquery(column, subQuery, true)
 }
 
 // OrWhereInSubquery method allows you to add an "or" clause to WhereInSubquery condition.
@@ -280,12 +689,115 @@ func (c *WhereContainer[T]) WhereInSubquery(column string, subQuery func(q Selec
 //
 //	    log.Println(query) => SELECT * FROM users WHERE age >= OR users.id IN (SELECT user_id FROM orders ORDER BY total_price DESC LIMIT $1)
 func (c *WhereContainer[T]) OrWhereInSubquery(column string, subQuery func(q SelectQuery) string) T {
+	return c.whereInSubquery(column, subQuery, false)
+}
+
+// whereInSubquery calls the callback when the query is built, handing it a query rendered in the
+// outer query's env. The callback only returns SQL, so its errors can't be returned as they are.
+func (c *WhereContainer[T]) whereInSubquery(column string, subQuery func(q SelectQuery) string, isAnd bool) T {
 	c.conditions = append(c.conditions, whereStructure{
-		rawQuery: fmt.Sprintf("%s IN (%s)", column, subQuery(SelectQuery{
-			withoutRebinding: true,
-		})),
-		isAnd: false,
-		isRaw: true,
+		isAnd: isAnd,
+		build: func(e env) (string, []any, error) {
+			q := SelectQuery{outer: &e}
+			q.dialect = e.dialect
+
+			sub := subQuery(q)
+			if sub == "" {
+				return "", nil, fmt.Errorf("ququery: subquery of %s can't be built, use WhereInSub to get its error", column)
+			}
+
+			query := fmt.Sprintf("%s IN (%s)", column, sub)
+
+			return query, placeholders(query), nil
+		},
+	})
+
+	return c.self
+}
+
+// WhereDate method compares the date part of the column's value.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereDate("created_at", ">=").Query()
+//	log.Println(query) => SELECT * FROM users WHERE created_at::date >= $1
+func (c *WhereContainer[T]) WhereDate(column ...string) T {
+	return c.whereDatePart(dateOnly, column, true)
+}
+
+// OrWhereDate method allows you to add an "or" clause to WhereDate condition.
+func (c *WhereContainer[T]) OrWhereDate(column ...string) T {
+	return c.whereDatePart(dateOnly, column, false)
+}
+
+// WhereTime method compares the time part of the column's value.
+//
+// Example:
+//
+//	query := ququery.Select("users").Dialect(ququery.MySQL).WhereTime("created_at", "<").Query()
+//	log.Println(query) => SELECT * FROM users WHERE TIME(created_at) < ?
+func (c *WhereContainer[T]) WhereTime(column ...string) T {
+	return c.whereDatePart(timeOnly, column, true)
+}
+
+// OrWhereTime method allows you to add an "or" clause to WhereTime condition.
+func (c *WhereContainer[T]) OrWhereTime(column ...string) T {
+	return c.whereDatePart(timeOnly, column, false)
+}
+
+// WhereYear method compares the year of the column's value.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereYear("created_at").Query()
+//	log.Println(query) => SELECT * FROM users WHERE EXTRACT(YEAR FROM created_at) = $1
+func (c *WhereContainer[T]) WhereYear(column ...string) T {
+	return c.whereDatePart(yearPart, column, true)
+}
+
+// OrWhereYear method allows you to add an "or" clause to WhereYear condition.
+func (c *WhereContainer[T]) OrWhereYear(column ...string) T {
+	return c.whereDatePart(yearPart, column, false)
+}
+
+// WhereMonth method compares the month of the column's value.
+//
+// Example:
+//
+//	query := ququery.Select("users").Dialect(ququery.SQLite).WhereMonth("created_at").Query()
+//	log.Println(query) => SELECT * FROM users WHERE CAST(strftime('%m', created_at) AS INTEGER) = ?
+func (c *WhereContainer[T]) WhereMonth(column ...string) T {
+	return c.whereDatePart(monthPart, column, true)
+}
+
+// OrWhereMonth method allows you to add an "or" clause to WhereMonth condition.
+func (c *WhereContainer[T]) OrWhereMonth(column ...string) T {
+	return c.whereDatePart(monthPart, column, false)
+}
+
+// WhereDay method compares the day of month of the column's value.
+//
+// Example:
+//
+//	query := ququery.Select("users").WhereDay("created_at").Query()
+//	log.Println(query) => SELECT * FROM users WHERE EXTRACT(DAY FROM created_at) = $1
+func (c *WhereContainer[T]) WhereDay(column ...string) T {
+	return c.whereDatePart(dayPart, column, true)
+}
+
+// OrWhereDay method allows you to add an "or" clause to WhereDay condition.
+func (c *WhereContainer[T]) OrWhereDay(column ...string) T {
+	return c.whereDatePart(dayPart, column, false)
+}
+
+func (c *WhereContainer[T]) whereDatePart(part datePart, column []string, isAnd bool) T {
+	op := c.checkOperator(column)
+
+	c.conditions = append(c.conditions, whereStructure{
+		column:   column[0],
+		operator: op,
+		datePart: part,
+		isAnd:    isAnd,
 	})
 
 	return c.self
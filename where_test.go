@@ -67,6 +67,76 @@ func TestWhereContainer_TextSearch(t *testing.T) {
 	testutil.RunTests(t, testcases, nil)
 }
 
+func TestWhereContainer_CaseInsensitiveLike(t *testing.T) {
+	testcases := testutil.Testcases{
+		"query with whereNotLike and orWhereNotLike": {
+			Query:       ququery.Select("users").WhereNotLike("email").OrWhereNotLike("name").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE email NOT LIKE $1 OR name NOT LIKE $2",
+			Doc:         "select users where email or name is not like x",
+		},
+		"query with whereILike on postgresql": {
+			Query:       ququery.Select("users").WhereILike("name").OrWhereNotILike("email").Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE name ILIKE $1 OR email NOT ILIKE $2",
+			Doc:         "select users where name is like x ignoring case",
+		},
+		"query with whereILike on mysql": {
+			Query: ququery.Select("users").
+				Dialect(ququery.MySQL).
+				Where("id", ">").
+				OrWhereILike("name").
+				WhereNotILike("email").
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE id > ? OR LOWER(name) LIKE LOWER(?) AND LOWER(email) NOT LIKE LOWER(?)",
+			Doc:         "select users where name is like x ignoring case on mysql",
+		},
+		"query with whereLikeCollate on mysql": {
+			Query: ququery.Select("users").
+				Dialect(ququery.MySQL).
+				WhereLikeCollate("name", "utf8mb4_bin").
+				OrWhereLikeCollate("email", "utf8mb4_0900_ai_ci").
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE name LIKE ? COLLATE utf8mb4_bin OR email LIKE ? COLLATE utf8mb4_0900_ai_ci",
+			Doc:         "select users where name is like x with case-sensitive collation on mysql",
+		},
+		"query with whereLikeCollate on postgresql": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnsupported,
+			Doc:         "LIKE collation is only supported on mysql",
+		}.Build(ququery.Select("users").WhereLikeCollate("name", "C"), "x"),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_EscapedLike(t *testing.T) {
+	testcases := testutil.Testcases{
+		"query with whereContains": testutil.Testcase{
+			ExpectedSQL:  `SELECT * FROM users WHERE name LIKE $1 ESCAPE '\'`,
+			ExpectedArgs: []any{`%50\%\_off\\%`},
+			Doc:          "select users where name contains a term with wildcards",
+		}.Build(ququery.Select("users").WhereContains("name", `50%_off\`)),
+		"query with whereStartsWith and orWhereEndsWith": testutil.Testcase{
+			ExpectedSQL:  `SELECT * FROM users WHERE id > $1 AND name LIKE $2 ESCAPE '\' OR email LIKE $3 ESCAPE '\'`,
+			ExpectedArgs: []any{10, "adel%", "%@example.com"},
+			Doc:          "select users where name starts with x or email ends with y",
+		}.Build(ququery.Select("users").
+			Where("id", ">").
+			WhereStartsWith("name", "adel").
+			OrWhereEndsWith("email", "@example.com"), 10),
+		"query with orWhereContains, whereEndsWith and orWhereStartsWith on mysql": testutil.Testcase{
+			ExpectedSQL:  `SELECT * FROM users WHERE name LIKE ? ESCAPE '\\' OR email LIKE ? ESCAPE '\\' AND phone LIKE ? ESCAPE '\\' OR phone LIKE ? ESCAPE '\\'`,
+			ExpectedArgs: []any{"%a%", "%b%", "%c", "d%"},
+			Doc:          "backslashes are escaped in string literals on mysql",
+		}.Build(ququery.Select("users").
+			Dialect(ququery.MySQL).
+			WhereContains("name", "a").
+			OrWhereContains("email", "b").
+			WhereEndsWith("phone", "c").
+			OrWhereStartsWith("phone", "d")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
 func TestWhereContainer_WhereInSubquery(t *testing.T) {
 	testcases := testutil.Testcases{
 		"query with whereInSubquery": {
@@ -91,6 +161,212 @@ func TestWhereContainer_WhereInSubquery(t *testing.T) {
 			}).Query(),
 			ExpectedSQL: "SELECT * FROM users WHERE role_id = $1 OR users.id IN (SELECT user_id FROM orders ORDER BY orders.id ASC)",
 		},
+		"callback building another query": {
+			Query: ququery.Select("users").WhereInSubquery("users.id", func(ququery.SelectQuery) string {
+				return "SELECT user_id FROM orders"
+			}).Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE users.id IN (SELECT user_id FROM orders)",
+			Doc:         "the SQL of the callback is used as is",
+		},
+		"callback rendered with the outer dialect": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE users.id IN (SELECT user_id FROM orders WHERE paid = ?)",
+			ExpectedArgs: []any{true},
+			Doc:          "the callback is called when the query is built",
+		}.Build(ququery.Select("users").WhereInSubquery("users.id", func(q ququery.SelectQuery) string {
+			return q.Table("orders").Columns("user_id").Where("paid").Query()
+		}).Dialect(ququery.MySQL), true),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+
+	unknownOperator := ququery.Select("users").WhereInSubquery("users.id", func(q ququery.SelectQuery) string {
+		return q.Table("orders").Columns("user_id").Where("total", "~~~").Query()
+	})
+
+	if _, _, err := unknownOperator.Build(1); err == nil {
+		t.Error("expected an error for a subquery that can't be built")
+	}
+}
+
+func TestWhereContainer_DateConditions(t *testing.T) {
+	testcases := testutil.Testcases{
+		"where date on postgresql": {
+			Query:       ququery.Select("orders").WhereDate("created_at", ">=").Query(),
+			ExpectedSQL: "SELECT * FROM orders WHERE created_at::date >= $1",
+			Doc:         "select orders created on or after a given date",
+		},
+		"where year and or where month on postgresql": {
+			Query: ququery.Select("orders").
+				WhereYear("created_at").
+				OrWhereMonth("created_at", ">").
+				Query(),
+			ExpectedSQL: "SELECT * FROM orders WHERE EXTRACT(YEAR FROM created_at) = $1 OR EXTRACT(MONTH FROM created_at) > $2",
+			Doc:         "select orders of a year or after a month",
+		},
+		"where date and time on mysql": {
+			Query: ququery.Select("orders").
+				Dialect(ququery.MySQL).
+				WhereDate("created_at").
+				WhereTime("created_at", "<").
+				Query(),
+			ExpectedSQL: "SELECT * FROM orders WHERE DATE(created_at) = ? AND TIME(created_at) < ?",
+			Doc:         "select orders of a day before a given time on mysql",
+		},
+		"where year, month and day on mysql": {
+			Query: ququery.Delete("orders").
+				Dialect(ququery.MySQL).
+				WhereYear("created_at").
+				WhereMonth("created_at").
+				OrWhereDay("created_at").
+				Query(),
+			ExpectedSQL: "DELETE FROM orders WHERE YEAR(created_at) = ? AND MONTH(created_at) = ? OR DAY(created_at) = ?",
+			Doc:         "delete orders by year, month or day on mysql",
+		},
+		"where date parts on sqlite": {
+			Query: ququery.Exists("orders").
+				Dialect(ququery.SQLite).
+				WhereDate("created_at").
+				OrWhereTime("created_at").
+				WhereYear("created_at").
+				WhereDay("created_at").
+				Query(),
+			ExpectedSQL: "SELECT EXISTS(SELECT true FROM orders WHERE date(created_at) = ? OR time(created_at) = ? AND CAST(strftime('%Y', created_at) AS INTEGER) = ? AND CAST(strftime('%d', created_at) AS INTEGER) = ?)",
+			Doc:         "check orders exist by date parts on sqlite",
+		},
+		"subquery inherits dialect": {
+			Query: ququery.Select("users").
+				Dialect(ququery.MySQL).
+				WhereInSubquery("users.id", func(q ququery.SelectQuery) string {
+					return q.Table("orders").
+						Columns("user_id").
+						WhereYear("created_at").
+						Query()
+				}).
+				Where("id").
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE users.id IN (SELECT user_id FROM orders WHERE YEAR(created_at) = ?) AND id = ?",
+			Doc:         "subqueries are rendered with the parent's dialect",
+		},
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_WhereIn(t *testing.T) {
+	testcases := testutil.Testcases{
+		"query with whereIn": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE id > $1 AND role_id IN ($2, $3, $4)",
+			ExpectedArgs: []any{10, 1, 2, 3},
+			Doc:          "select users with one of the given roles",
+		}.Build(ququery.Select("users").Where("id", ">").WhereIn("role_id", 1, 2, 3), 10),
+		"query with orWhereIn and whereNotIn": testutil.Testcase{
+			ExpectedSQL:  "DELETE FROM users WHERE id IN ($1) OR status NOT IN ($2, $3)",
+			ExpectedArgs: []any{1, "banned", "deleted"},
+			Doc:          "delete a user or users that are not banned or deleted",
+		}.Build(ququery.Delete("users").WhereIn("id", 1).OrWhereNotIn("status", "banned", "deleted")),
+		"query with empty whereIn and orWhereNotIn": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM users WHERE 1 = 0 OR 1 = 1",
+			ExpectedArgs: []any{},
+			Doc:          "empty lists never match for in and always match for not in",
+		}.Build(ququery.Select("users").WhereIn("id").OrWhereNotIn("id")),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_WhereGroup(t *testing.T) {
+	testcases := testutil.Testcases{
+		"nested groups with bound arguments": testutil.Testcase{
+			ExpectedSQL:  "SELECT * FROM documents WHERE owner_id = $1 AND (public = $2 AND (team_id IN ($3, $4) AND (role LIKE $5 ESCAPE '\\' AND deleted_at IS NULL)))",
+			ExpectedArgs: []any{7, true, 1, 2, "admin%"},
+			Doc:          "permission filter with three levels of nesting",
+		}.Build(ququery.Select("documents").
+			Where("owner_id").
+			WhereGroup(func(q *ququery.MultiWhere) {
+				q.Where("public").
+					WhereGroup(func(q *ququery.MultiWhere) {
+						q.WhereIn("team_id", 1, 2).
+							WhereGroup(func(q *ququery.MultiWhere) {
+								q.WhereStartsWith("role", "admin").WhereNull("deleted_at")
+							})
+					})
+			}), 7, true),
+		"group in update query": testutil.Testcase{
+			ExpectedSQL:  "UPDATE users SET status = ? WHERE (id = ? OR email = ?)",
+			ExpectedArgs: []any{"active", 1, "a@b.c"},
+			Doc:          "groups follow the query's dialect",
+		}.Build(ququery.Update("users").
+			Dialect(ququery.MySQL).
+			Set("status").
+			WhereGroup(func(q *ququery.MultiWhere) {
+				q.Where("id").OrWhere("email")
+			}), "active", 1, "a@b.c"),
+		"empty group": {
+			Query: ququery.Select("users").
+				Where("id").
+				WhereGroup(func(q *ququery.MultiWhere) {}).
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE id = $1",
+			Doc:         "empty groups are left out of the query",
+		},
+		"group with unknown operator": testutil.Testcase{
+			ExpectedErr: ququery.ErrUnknownOperator,
+			Doc:         "groups validate their operators",
+		}.Build(ququery.Select("users").WhereGroup(func(q *ququery.MultiWhere) {
+			q.Where("id", "===")
+		}), 1),
+	}
+
+	testutil.RunTests(t, testcases, nil)
+}
+
+func TestWhereContainer_OrAndNotGroups(t *testing.T) {
+	testcases := testutil.Testcases{
+		"query with orWhereGroup": {
+			Query: ququery.Select("users").
+				Where("role").
+				OrWhereGroup(func(q *ququery.MultiWhere) {
+					q.Where("role").Where("team_id")
+				}).
+				Query(),
+			ExpectedSQL: "SELECT * FROM users WHERE role = $1 OR (role = $2 AND team_id = $3)",
+			Doc:         "select users with a role or with another role in a team",
+		},
+		"query with whereNotGroup": {
+			Query: ququery.Exists("users").
+				Where("email").
+				WhereNotGroup(func(q *ququery.MultiWhere) {
+					q.Where("banned").OrWhereNotNull("deleted_at")
+				}).
+				Query(),
+			ExpectedSQL: "SELECT EXISTS(SELECT true FROM users WHERE email = $1 AND NOT (banned = $2 OR deleted_at IS NOT NULL))",
+			Doc:         "check a user that is neither banned nor deleted exists",
+		},
+		"query with orWhereNotGroup in nested group": {
+			Query: ququery.Delete("sessions").
+				WhereGroup(func(q *ququery.MultiWhere) {
+					q.Where("expired").
+						OrWhereNotGroup(func(q *ququery.MultiWhere) {
+							q.Where("user_id").OrWhereGroup(func(q *ququery.MultiWhere) {
+								q.WhereNull("device_id")
+							})
+						})
+				}).
+				Query(),
+			ExpectedSQL: "DELETE FROM sessions WHERE (expired = $1 OR NOT (user_id = $2 OR (device_id IS NULL)))",
+			Doc:         "delete expired sessions or sessions of other users",
+		},
+		"query with groups in update": {
+			Query: ququery.Update("users").
+				Set("status").
+				Where("id").
+				OrWhereNotGroup(func(q *ququery.MultiWhere) {
+					q.WhereIn("role", "admin")
+				}).
+				Query(),
+			ExpectedSQL: "UPDATE users SET status = $1 WHERE id = $2 OR NOT (role IN ($3))",
+			Doc:         "update users by id or users that are not admins",
+		},
 	}
 
 	testutil.RunTests(t, testcases, nil)